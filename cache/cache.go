@@ -3,9 +3,11 @@ package cache
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 const (
@@ -14,25 +16,72 @@ const (
 	TimesheetsCacheFileName    = "timesheets_%d_%04d_%02d.json"
 	NonBusinessCacheFileName   = "nonbusiness_%04d_%02d.json"
 	TicketsCacheFileName       = "tickets_%s.json"
+	WatchCacheFileName         = "watch_%s.json"
+	WatchTextsCacheFileName    = "watch_texts_%s.json"
+	DaemonCacheFileName        = "daemon_%s.json"
+	SupportInfoCacheFileName   = "support_%s.json"
+	SearchDocsCacheFileName    = "search_docs.json"
+	SearchIndexCacheFileName   = "search_index.json"
 	EmployeeListCacheFileName  = "employeesList.json"
 	ContractsListCacheFileName = "contractsList.json"
+	BudgetCacheFileName        = "budget_%s.json"
+	TicketTrendCacheFileName   = "ticket_trend_%s.json"
 )
 
+// scope is the subdirectory holding the cache of the Mantis instance in
+// use, so profiles pointing at different instances never share data.
+var scope string
+
+// SetScope keys the cache on the Mantis instance at baseURL. Each host
+// gets its own subdirectory; an empty baseURL, the configured instance,
+// uses the cache directory itself.
+func SetScope(baseURL string) {
+	scope = scopeDirName(baseURL)
+}
+
+func scopeDirName(baseURL string) string {
+	if baseURL == "" {
+		return ""
+	}
+	name := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		name = u.Host
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
 func GetCacheFilePath(cacheFileName string) (string, error) {
-	homeDir, err := os.UserHomeDir()
+	cacheDirPath, err := GetCacheDirPath()
 	if err != nil {
-		return "", fmt.Errorf("could not get user home directory: %w", err)
+		return "", err
 	}
-	cacheDirPath := filepath.Join(homeDir, cacheDirName, appName)
 	return filepath.Join(cacheDirPath, cacheFileName), nil
 }
 
+// GetCacheModTime returns when the cache file was last written.
+func GetCacheModTime(cacheFileName string) (time.Time, error) {
+	cacheFilePath, err := GetCacheFilePath(cacheFileName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	info, err := os.Stat(cacheFilePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
 func EnsureCacheDirExists() error {
-	homeDir, err := os.UserHomeDir()
+	cacheDirPath, err := GetCacheDirPath()
 	if err != nil {
-		return fmt.Errorf("could not get user home directory: %w", err)
+		return err
 	}
-	cacheDirPath := filepath.Join(homeDir, cacheDirName, appName)
 	return os.MkdirAll(cacheDirPath, 0755)
 }
 
@@ -83,7 +132,7 @@ func GetCacheDirPath() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("could not get user home directory: %w", err)
 	}
-	return filepath.Join(homeDir, cacheDirName, appName), nil
+	return filepath.Join(homeDir, cacheDirName, appName, scope), nil
 }
 
 func ListCacheFiles(prefix string) ([]string, error) {
@@ -112,3 +161,22 @@ func ListCacheFiles(prefix string) ([]string, error) {
 
 	return files, nil
 }
+
+// ClearCacheDir removes every cache file of the current scope. The
+// subdirectories of other instances are left alone.
+func ClearCacheDir() error {
+	files, err := ListCacheFiles("")
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		path, err := GetCacheFilePath(name)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
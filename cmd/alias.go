@@ -0,0 +1,429 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+var (
+	aliasAddLine     int
+	aliasAddForce    bool
+	aliasPruneDryRun bool
+	aliasPruneYes    bool
+	aliasSyncDryRun  bool
+	aliasSyncAdd     bool
+
+	aliasSetTicket      string
+	aliasSetType        string
+	aliasSetDescription string
+	aliasSetHours       string
+)
+
+func init() {
+	aliasAddCmd.Flags().IntVar(&aliasAddLine, "line", 0, "Sales order line, when the project has more than one")
+	aliasAddCmd.Flags().BoolVarP(&aliasAddForce, "force", "f", false, "Overwrite an existing alias")
+	aliasPruneCmd.Flags().BoolVar(&aliasPruneDryRun, "dry-run", false, "Only show what would be removed")
+	aliasPruneCmd.Flags().BoolVarP(&aliasPruneYes, "yes", "y", false, "Do not ask for confirmation")
+	aliasSyncCmd.Flags().BoolVar(&aliasSyncDryRun, "dry-run", false, "Only show what would change")
+	aliasSyncCmd.Flags().BoolVar(&aliasSyncAdd, "add", false, "Also create the suggested aliases")
+	aliasSetCmd.Flags().StringVarP(&aliasSetTicket, "ticket", "t", "", "Default ticket number")
+	aliasSetCmd.Flags().StringVarP(&aliasSetType, "type", "T", "", "Default timesheet type")
+	aliasSetCmd.Flags().StringVarP(&aliasSetDescription, "description", "d", "", "Default description; may use {{date}} and {{weekday}}")
+	aliasSetCmd.Flags().StringVarP(&aliasSetHours, "hours", "H", "", "Default duration, e.g. 1h30m")
+
+	aliasAddCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	for _, c := range []*cobra.Command{aliasRmCmd, aliasRenameCmd, aliasSetCmd} {
+		c.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if c != aliasRmCmd && len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return projectAliasCompletionFunc(cmd, args, toComplete)
+		}
+	}
+
+	aliasCmd.AddCommand(aliasListCmd, aliasAddCmd, aliasRmCmd, aliasRenameCmd, aliasSetCmd, aliasPruneCmd, aliasSyncCmd)
+	rootCmd.AddCommand(aliasCmd)
+}
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage the project aliases of the current profile",
+	Long: `Project aliases name a sales order line so appointments can use
+--project-alias instead of the numbers. They belong to the current profile.
+
+Examples:
+  intracli alias list
+  intracli alias add support 4100123
+  intracli alias rename support sustentacao
+  intracli alias set daily -t 12345 -H 15m -d "Daily {{weekday}}"
+  intracli alias rm old-project
+  intracli alias prune --dry-run
+  intracli alias sync --add`,
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List aliases with their project and whether it is still assigned",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, profile := currentProfileEntry()
+		if len(profile.ProjectAliases) == 0 {
+			fmt.Println(i18n.T("Profile '%s' has no aliases. Add one with 'intracli alias add'.", name))
+			return
+		}
+		projects := assignedProjects()
+
+		table := tablewriter.NewTable(os.Stdout,
+			tablewriter.WithConfig(tablewriter.Config{
+				Row: tw.CellConfig{
+					Alignment:    tw.CellAlignment{Global: tw.AlignLeft},
+					ColMaxWidths: tw.CellWidth{Global: 50},
+				},
+			}),
+		)
+		table.Header(i18n.T("Alias"), i18n.T("Project"), "SalesOrder", i18n.T("Line"), i18n.T("Needs Ticket"), i18n.T("Assigned"), i18n.T("Defaults"))
+		for _, alias := range slices.Sorted(maps.Keys(profile.ProjectAliases)) {
+			a := profile.ProjectAliases[alias]
+			title := ""
+			assigned := utils.ErrorStyle.Sprint(i18n.T("no"))
+			if p, ok := findProject(projects, a.SalesOrder, a.SalesOrderLine); ok {
+				title = p.ProjectTitle
+				assigned = utils.SuccessStyle.Sprint(i18n.T("yes"))
+			}
+			table.Append(alias, title, strconv.Itoa(a.SalesOrder), strconv.Itoa(a.SalesOrderLine), strconv.FormatBool(a.NeedsTicket), assigned, aliasDefaultsSummary(a))
+		}
+		table.Render()
+	},
+}
+
+var aliasAddCmd = &cobra.Command{
+	Use:   "add NAME PROJECT_NUMBER",
+	Short: "Alias one of your assigned projects",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		alias := args[0]
+		number, err := strconv.Atoi(args[1])
+		if err != nil {
+			log.Fatal(i18n.T("Invalid project number %q", args[1]))
+		}
+		name, profile := currentProfileEntry()
+		if _, exists := profile.ProjectAliases[alias]; exists && !aliasAddForce {
+			log.Fatal(i18n.T("Alias '%s' already exists. Use --force to overwrite it.", alias))
+		}
+
+		var matches []mantis.ProjectTimesheet
+		for _, p := range assignedProjects() {
+			if p.ProjectNumber == number && (aliasAddLine == 0 || p.EmployeeLineNumber == aliasAddLine) {
+				matches = append(matches, p)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			log.Fatal(i18n.T("Project %d is not assigned to you. See 'intracli list-projects'.", number))
+		case 1:
+		default:
+			var lines []string
+			for _, p := range matches {
+				lines = append(lines, strconv.Itoa(p.EmployeeLineNumber))
+			}
+			log.Fatal(i18n.T("Project %d has several lines (%s); pick one with --line.", number, strings.Join(lines, ", ")))
+		}
+
+		p := matches[0]
+		if profile.ProjectAliases == nil {
+			profile.ProjectAliases = map[string]config.ProjectAlias{}
+		}
+		a := profile.ProjectAliases[alias]
+		a.SalesOrder, a.SalesOrderLine, a.NeedsTicket = p.ProjectNumber, p.EmployeeLineNumber, p.ProjectNeedTicket
+		profile.ProjectAliases[alias] = a
+		saveProfileEntry(name, profile)
+		fmt.Println(i18n.T("Alias '%s' saved for project %d (%s).", alias, p.ProjectNumber, p.ProjectTitle))
+	},
+}
+
+var aliasRmCmd = &cobra.Command{
+	Use:         "rm NAME...",
+	Short:       "Remove aliases",
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		name, profile := currentProfileEntry()
+		for _, alias := range args {
+			if _, ok := profile.ProjectAliases[alias]; !ok {
+				log.Fatal(i18n.T("Alias '%s' not found in profile '%s'.", alias, name))
+			}
+		}
+		for _, alias := range args {
+			delete(profile.ProjectAliases, alias)
+		}
+		saveProfileEntry(name, profile)
+		fmt.Println(i18n.T("Removed %s.", strings.Join(args, ", ")))
+	},
+}
+
+var aliasRenameCmd = &cobra.Command{
+	Use:         "rename OLD NEW",
+	Short:       "Rename an alias",
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		old, alias := args[0], args[1]
+		name, profile := currentProfileEntry()
+		a, ok := profile.ProjectAliases[old]
+		if !ok {
+			log.Fatal(i18n.T("Alias '%s' not found in profile '%s'.", old, name))
+		}
+		if _, exists := profile.ProjectAliases[alias]; exists {
+			log.Fatal(i18n.T("Alias '%s' already exists.", alias))
+		}
+		delete(profile.ProjectAliases, old)
+		profile.ProjectAliases[alias] = a
+		saveProfileEntry(name, profile)
+		fmt.Println(i18n.T("Renamed alias '%s' to '%s'.", old, alias))
+	},
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set NAME",
+	Short: "Set the appointment defaults of an alias",
+	Long: `Sets what appointments on the alias default to when the flags leave it
+out. An empty value clears a default. The description may use {{date}}
+and {{weekday}}, filled with the appointment date.
+
+Examples:
+  intracli alias set daily --ticket 12345 --hours 15m --description "Daily {{weekday}}"
+  intracli alias set support --type Normal
+  intracli alias set daily --ticket ""`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		alias := args[0]
+		name, profile := currentProfileEntry()
+		a, ok := profile.ProjectAliases[alias]
+		if !ok {
+			log.Fatal(i18n.T("Alias '%s' not found in profile '%s'.", alias, name))
+		}
+		if cmd.Flags().NFlag() == 0 {
+			log.Fatal(i18n.T("Nothing to set; see 'intracli alias set --help'."))
+		}
+
+		flags := cmd.Flags()
+		if flags.Changed("ticket") {
+			a.Ticket = aliasSetTicket
+		}
+		if flags.Changed("type") {
+			a.Type = aliasSetType
+		}
+		if flags.Changed("description") {
+			a.Description = aliasSetDescription
+		}
+		if flags.Changed("hours") {
+			if _, err := parseDurationString(aliasSetHours); err != nil {
+				log.Fatal(err)
+			}
+			a.Hours = aliasSetHours
+		}
+		profile.ProjectAliases[alias] = a
+		saveProfileEntry(name, profile)
+
+		if summary := aliasDefaultsSummary(a); summary != "" {
+			fmt.Println(i18n.T("Alias '%s' defaults: %s", alias, summary))
+		} else {
+			fmt.Println(i18n.T("Alias '%s' has no defaults.", alias))
+		}
+	},
+}
+
+var aliasPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove aliases of projects no longer assigned to you",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, profile := currentProfileEntry()
+		projects := assignedProjects()
+
+		var stale []string
+		for _, alias := range slices.Sorted(maps.Keys(profile.ProjectAliases)) {
+			a := profile.ProjectAliases[alias]
+			if _, ok := findProject(projects, a.SalesOrder, a.SalesOrderLine); !ok {
+				stale = append(stale, alias)
+				fmt.Printf("  %s  %s\n", alias, utils.MutedStyle.Sprint(i18n.T("sales order %d line %d", a.SalesOrder, a.SalesOrderLine)))
+			}
+		}
+		if len(stale) == 0 {
+			fmt.Println(i18n.T("Every alias is still assigned."))
+			return
+		}
+		if aliasPruneDryRun {
+			fmt.Println(i18n.T("%d alias(es) would be removed.", len(stale)))
+			return
+		}
+		if !aliasPruneYes && !confirm(i18n.T("Remove %d alias(es)?", len(stale))) {
+			fmt.Println(i18n.T("Aborted."))
+			return
+		}
+		for _, alias := range stale {
+			delete(profile.ProjectAliases, alias)
+		}
+		saveProfileEntry(name, profile)
+		fmt.Println(i18n.T("Removed %d alias(es).", len(stale)))
+	},
+}
+
+var aliasSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Refresh needsTicket and suggest aliases for new projects",
+	Long: `Updates the needsTicket flag of every alias from Mantis, and suggests an
+alias for each assigned project that has none. --add creates the
+suggestions.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, profile := currentProfileEntry()
+		if profile.ProjectAliases == nil {
+			profile.ProjectAliases = map[string]config.ProjectAlias{}
+		}
+		projects := assignedProjects()
+
+		changed := 0
+		for _, alias := range slices.Sorted(maps.Keys(profile.ProjectAliases)) {
+			a := profile.ProjectAliases[alias]
+			p, ok := findProject(projects, a.SalesOrder, a.SalesOrderLine)
+			if !ok || p.ProjectNeedTicket == a.NeedsTicket {
+				continue
+			}
+			fmt.Println(i18n.T("  %s: needsTicket %t → %t", alias, a.NeedsTicket, p.ProjectNeedTicket))
+			a.NeedsTicket = p.ProjectNeedTicket
+			profile.ProjectAliases[alias] = a
+			changed++
+		}
+
+		var suggested int
+		taken := maps.Clone(profile.ProjectAliases)
+		for _, p := range projects {
+			if projectAliasFor(profile, p.ProjectNumber, p.EmployeeLineNumber) != "" {
+				continue
+			}
+			alias := suggestAlias(p, taken)
+			taken[alias] = config.ProjectAlias{}
+			if aliasSyncAdd && !aliasSyncDryRun {
+				profile.ProjectAliases[alias] = config.ProjectAlias{
+					SalesOrder:     p.ProjectNumber,
+					SalesOrderLine: p.EmployeeLineNumber,
+					NeedsTicket:    p.ProjectNeedTicket,
+				}
+				fmt.Println(i18n.T("  added %s for %d (%s)", alias, p.ProjectNumber, p.ProjectTitle))
+				changed++
+			} else {
+				fmt.Printf("  %s  %s\n", utils.MutedStyle.Sprintf("intracli alias add %s %d --line %d", alias, p.ProjectNumber, p.EmployeeLineNumber), p.ProjectTitle)
+			}
+			suggested++
+		}
+
+		switch {
+		case changed == 0 && suggested == 0:
+			fmt.Println(i18n.T("Aliases are up to date."))
+		case changed == 0:
+		case aliasSyncDryRun:
+			fmt.Println(i18n.T("%d change(s) not saved (--dry-run).", changed))
+		default:
+			saveProfileEntry(name, profile)
+			fmt.Println(i18n.T("Saved %d change(s) to profile '%s'.", changed, name))
+		}
+	},
+}
+
+// currentProfileEntry is the active profile and its name.
+func currentProfileEntry() (string, config.Profile) {
+	name := activeProfileName(appConfig)
+	profile, ok := appConfig.Profiles[name]
+	if !ok {
+		log.Fatal(i18n.T("Profile '%s' not found in config", name))
+	}
+	return name, profile
+}
+
+func saveProfileEntry(name string, profile config.Profile) {
+	appConfig.Profiles[name] = profile
+	if err := config.SaveConfig(appConfig); err != nil {
+		log.Fatal(i18n.T("Failed to save config: %v", err))
+	}
+}
+
+// aliasDefaultsSummary describes the appointment defaults of a on one line.
+func aliasDefaultsSummary(a config.ProjectAlias) string {
+	var parts []string
+	if a.Hours != "" {
+		parts = append(parts, a.Hours)
+	}
+	if a.Ticket != "" {
+		parts = append(parts, "#"+a.Ticket)
+	}
+	if a.Type != "" {
+		parts = append(parts, a.Type)
+	}
+	if a.Description != "" {
+		parts = append(parts, strconv.Quote(a.Description))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// assignedProjects are the projects Mantis lists for the current employee.
+func assignedProjects() []mantis.ProjectTimesheet {
+	if currentUser.EmployeeCode == 0 {
+		log.Fatal(i18n.T("Employee code not found. Please run 'intracli search-employee' to update your profile."))
+	}
+	projects, err := mantisClient.Timesheet.GetProjectTimesheets(mantisCtx, currentUser.EmployeeCode)
+	if err != nil {
+		log.Fatal(i18n.T("Error getting projects: %v", err))
+	}
+	return projects
+}
+
+func findProject(projects []mantis.ProjectTimesheet, salesOrder, line int) (mantis.ProjectTimesheet, bool) {
+	for _, p := range projects {
+		if p.ProjectNumber == salesOrder && p.EmployeeLineNumber == line {
+			return p, true
+		}
+	}
+	return mantis.ProjectTimesheet{}, false
+}
+
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "ê", "e", "è", "e", "í", "i", "ó", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ü", "u", "ç", "c", "ñ", "n",
+)
+
+// suggestAlias makes an alias from the first two words of the project
+// title, numbered when taken.
+func suggestAlias(p mantis.ProjectTimesheet, taken map[string]config.ProjectAlias) string {
+	words := strings.FieldsFunc(accentFolder.Replace(strings.ToLower(p.ProjectTitle)), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	base := strings.Join(words[:min(2, len(words))], "-")
+	if base == "" {
+		base = "p" + strconv.Itoa(p.ProjectNumber)
+	}
+	alias := base
+	for i := 2; ; i++ {
+		if _, ok := taken[alias]; !ok {
+			return alias
+		}
+		alias = fmt.Sprintf("%s-%d", base, i)
+	}
+}
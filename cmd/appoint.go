@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/IntraCLI/types"
 	"github.com/Salvadego/mantis/mantis"
 
@@ -52,7 +53,8 @@ var appointCmd = &cobra.Command{
 	Short: "Make a new appointment",
 	Long: `This command allows you to create a new timesheet appointment with
 	details like description, hours, date, ticket number (optional), and a
-	project alias.`,
+	project alias. Flags left out take the defaults of the project alias
+	(see 'intracli alias set').`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := mantisClient
 		ctx := mantisCtx
@@ -66,7 +68,11 @@ var appointCmd = &cobra.Command{
 
 		profile, ok := cfg.Profiles[currentProfileName]
 		if !ok {
-			log.Fatalf("Profile '%s' not found in configuration. Please check your config.yaml.", currentProfileName)
+			log.Fatal(i18n.T("Profile '%s' not found in configuration. Please check your config.yaml.", currentProfileName))
+		}
+
+		if a, ok := profile.ProjectAliases[projectAlias]; ok {
+			fillAliasDefaults(a, date, cmd.Flags().Changed, &ticket, &timesheetType, &hoursString, &description)
 		}
 
 		if useEditor {
@@ -75,57 +81,113 @@ var appointCmd = &cobra.Command{
 		}
 
 		if description == "" {
-			log.Fatalf("Missing required flags: --description")
+			log.Fatal(i18n.T("Missing required flags: --description"))
 		}
 
 		if hoursString == "" {
-			log.Fatalf("Missing required flags: --hours")
+			log.Fatal(i18n.T("Missing required flags: --hours"))
 		}
 
 		if projectAlias == "" && timesheetType != "T" {
-			log.Fatalf("Missing required flags: --project-alias")
+			log.Fatal(i18n.T("Missing required flags: --project-alias"))
 		}
 
-		var projectInfo config.ProjectAlias
-		if timesheetType != "T" {
-			projectInfo, ok = profile.ProjectAliases[projectAlias]
-			if !ok {
-				log.Fatalf("Project alias '%s' not found in your default profile.", projectAlias)
-			}
-			if projectInfo.NeedsTicket && ticket == "" {
-				log.Fatalf("Error: Project '%s' requires a ticket number. Please provide one using --ticket (-t).", projectAlias)
-			}
-		}
-
-		parsedHours, err := parseDurationString(hoursString)
+		timesheetEntry, err := newTimesheetEntry(profile, projectAlias, ticket, timesheetType, hoursString, date, description)
 		if err != nil {
-			log.Fatalf("Invalid hours format: %v", err)
+			log.Fatal(err)
 		}
 
-		parsedDate, err := time.Parse("2006-01-02", date)
-		if err != nil {
-			log.Fatalf("Invalid date format. Please use YYYY-MM-DD. Error: %v", err)
-		}
+		fmt.Println(i18n.T("Attempting to create appointment:\n%+v", timesheetEntry))
 
-		timesheetTypeKey := "N"
-		if key, ok := types.TimesheetTypeLookup[timesheetType]; ok {
-			timesheetTypeKey = key
-		}
+		appoint(client, userID, timesheetEntry, ctx)
+	},
+}
 
-		timesheetEntry := TimesheetEntry{
-			Date:           parsedDate.Format("2006-01-02"),
-			Description:    description,
-			TicketNo:       ticket,
-			TimesheetType:  timesheetTypeKey,
-			Hours:          parsedHours,
-			SalesOrder:     projectInfo.SalesOrder,
-			SalesOrderLine: projectInfo.SalesOrderLine,
+// newTimesheetEntry validates an appointment against the profile and builds
+// the entry to create. typeName is a timesheet type name ("T" skips the
+// project), hours a duration such as "1h30m" and day a YYYY-MM-DD date.
+func newTimesheetEntry(profile config.Profile, alias, ticketNo, typeName, hours, day, desc string) (TimesheetEntry, error) {
+	var projectInfo config.ProjectAlias
+	if typeName != "T" {
+		var ok bool
+		projectInfo, ok = profile.ProjectAliases[alias]
+		if !ok {
+			return TimesheetEntry{}, i18n.Errorf("Project alias '%s' not found in your default profile.", alias)
+		}
+		if projectInfo.NeedsTicket && ticketNo == "" {
+			return TimesheetEntry{}, i18n.Errorf("Error: Project '%s' requires a ticket number. Please provide one using --ticket (-t).", alias)
 		}
+	}
 
-		fmt.Printf("Attempting to create appointment:\n%+v\n", timesheetEntry)
+	parsedHours, err := parseDurationString(hours)
+	if err != nil {
+		return TimesheetEntry{}, i18n.Errorf("Invalid hours format: %v", err)
+	}
 
-		appoint(client, userID, timesheetEntry, ctx)
-	},
+	parsedDate, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return TimesheetEntry{}, i18n.Errorf("Invalid date format. Please use YYYY-MM-DD. Error: %v", err)
+	}
+
+	timesheetTypeKey := "N"
+	if key, ok := types.TimesheetTypeLookup[typeName]; ok {
+		timesheetTypeKey = key
+	}
+
+	return TimesheetEntry{
+		Date:           parsedDate.Format("2006-01-02"),
+		Description:    desc,
+		TicketNo:       ticketNo,
+		TimesheetType:  timesheetTypeKey,
+		Hours:          parsedHours,
+		SalesOrder:     projectInfo.SalesOrder,
+		SalesOrderLine: projectInfo.SalesOrderLine,
+	}, nil
+}
+
+// fillAliasDefaults sets the appointment fields not given by the user to
+// the defaults of alias a. given reports whether a field ("ticket", "type",
+// "hours" or "description") was given, even if empty. day (YYYY-MM-DD)
+// fills the description template.
+func fillAliasDefaults(a config.ProjectAlias, day string, given func(field string) bool, ticketNo, typeName, hours, desc *string) {
+	if !given("ticket") {
+		*ticketNo = a.Ticket
+	}
+	if !given("type") {
+		*typeName = a.Type
+	}
+	if !given("hours") {
+		*hours = a.Hours
+	}
+	if !given("description") {
+		*desc = expandDescription(a.Description, day)
+	}
+}
+
+// expandDescription fills the {{date}} and {{weekday}} placeholders of an
+// alias description. An invalid day leaves them as they are.
+func expandDescription(tmpl, day string) string {
+	d, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return tmpl
+	}
+	return strings.NewReplacer(
+		"{{date}}", day,
+		"{{weekday}}", i18n.Weekday(d.Weekday()),
+	).Replace(tmpl)
+}
+
+// timesheetEntryOf returns the entry that would recreate ts.
+func timesheetEntryOf(ts mantis.TimesheetsResponse) TimesheetEntry {
+	return TimesheetEntry{
+		Date:           ts.DateDoc[:10],
+		Description:    ts.Description,
+		TicketNo:       ts.TicketNo,
+		TimesheetType:  ts.TimesheetType,
+		Hours:          ts.Quantity,
+		SalesOrder:     int(ts.SalesOrder),
+		SalesOrderLine: int(ts.SalesOrderLine),
+	}
 }
 
 func parseDurationString(durationStr string) (float64, error) {
@@ -138,7 +200,7 @@ func parseDurationString(durationStr string) (float64, error) {
 		if err == nil {
 			return hours, nil
 		}
-		return 0, fmt.Errorf(
+		return 0, i18n.Errorf(
 			"invalid duration format: %s. Expected format like '8h' or '1d 2h'",
 			durationStr)
 	}
@@ -146,7 +208,7 @@ func parseDurationString(durationStr string) (float64, error) {
 	for _, match := range matches {
 		value, err := strconv.ParseFloat(match[1], 64)
 		if err != nil {
-			return 0, fmt.Errorf("invalid number in duration string: %s", match[1])
+			return 0, i18n.Errorf("invalid number in duration string: %s", match[1])
 		}
 		unit := match[2]
 
@@ -158,7 +220,7 @@ func parseDurationString(durationStr string) (float64, error) {
 		case "m":
 			totalHours += value / 60 // 1 minute = 1/60 hours
 		default:
-			return 0, fmt.Errorf("unknown unit '%s' in duration string", unit)
+			return 0, i18n.Errorf("unknown unit '%s' in duration string", unit)
 		}
 	}
 
@@ -189,23 +251,23 @@ func appoint(client *mantis.Client, userID int, entry TimesheetEntry, ctx contex
 		TimesheetType:       entry.TimesheetType,
 		TicketDescription:   "",
 	}
-	fmt.Println("Sending timesheet")
+	fmt.Println(i18n.T("Sending timesheet"))
 
 	err := client.Timesheet.Create(ctx, timesheet)
 	if err == nil {
-		fmt.Printf(
-			"Successfully created timesheet for %s with %.2f hours\n",
+		fmt.Println(i18n.T(
+			"Successfully created timesheet for %s with %.2f hours",
 			entry.Date,
 			entry.Hours,
-		)
+		))
 		return
 	}
 
-	fmt.Printf(
-		"Mantis error when creating timesheet for %s: %s\n",
+	fmt.Println(i18n.T(
+		"Mantis error when creating timesheet for %s: %s",
 		entry.Date,
 		err.Error(),
-	)
+	))
 
 }
 
@@ -225,12 +287,21 @@ func processEditorFile(profile config.Profile, client *mantis.Client, userID int
 # ticket: 12345
 # type: Normal
 #
+# Every block starts with description:, but it and the other fields
+# may be left empty when the project alias has a default for them.
+#
 # Save and close the file to create appointments.
 `
+	// With -p, start from a block filled with the flags and the alias
+	// defaults.
+	if projectAlias != "" {
+		template += fmt.Sprintf("\ndescription: %s\nhours: %s\ndate: %s\nproject-alias: %s\nticket: %s\ntype: %s\n",
+			description, hoursString, date, projectAlias, ticket, timesheetType)
+	}
 
 	err := os.WriteFile(file, []byte(template), 0644)
 	if err != nil {
-		log.Fatalf("Failed to write template to file: %v", err)
+		log.Fatal(i18n.T("Failed to write template to file: %v", err))
 	}
 
 	editor := os.Getenv("EDITOR")
@@ -245,19 +316,19 @@ func processEditorFile(profile config.Profile, client *mantis.Client, userID int
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
-		log.Fatalf("Error opening editor: %v", err)
+		log.Fatal(i18n.T("Error opening editor: %v", err))
 	}
 
 	contentBytes, err := os.ReadFile(file)
 	if err != nil {
-		log.Fatalf("Failed to read edited file: %v", err)
+		log.Fatal(i18n.T("Failed to read edited file: %v", err))
 	}
 	content := string(contentBytes)
 
 	re := regexp.MustCompile(`(?m)^description:`)
 	indices := re.FindAllStringIndex(content, -1)
 	if len(indices) == 0 {
-		fmt.Println("No appointments found.")
+		fmt.Println(i18n.T("No appointments found."))
 		return
 	}
 
@@ -282,36 +353,45 @@ func processEditorFile(profile config.Profile, client *mantis.Client, userID int
 			entryMap[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
 		}
 
-		if entryMap["description"] == "" || entryMap["hours"] == "" || entryMap["project-alias"] == "" {
+		if entryMap["project-alias"] == "" {
 			continue
 		}
 
 		projectInfo, ok := profile.ProjectAliases[entryMap["project-alias"]]
 		if !ok {
-			log.Printf("Skipping block: unknown project-alias '%s'", entryMap["project-alias"])
+			log.Print(i18n.T("Skipping block: unknown project-alias '%s'", entryMap["project-alias"]))
+			continue
+		}
+
+		entryDate := time.Now().Format("2006-01-02")
+		if entryMap["date"] != "" {
+			if _, err := time.Parse("2006-01-02", entryMap["date"]); err == nil {
+				entryDate = entryMap["date"]
+			} else {
+				log.Print(i18n.T("Invalid date, using today instead: %v", err))
+			}
+		}
+
+		blockTicket, blockType, blockHours, blockDesc := entryMap["ticket"], entryMap["type"], entryMap["hours"], entryMap["description"]
+		given := func(field string) bool { return entryMap[field] != "" }
+		fillAliasDefaults(projectInfo, entryDate, given, &blockTicket, &blockType, &blockHours, &blockDesc)
+		entryMap["ticket"], entryMap["type"], entryMap["hours"], entryMap["description"] = blockTicket, blockType, blockHours, blockDesc
+
+		if entryMap["description"] == "" || entryMap["hours"] == "" {
 			continue
 		}
 
 		if projectInfo.NeedsTicket && entryMap["ticket"] == "" {
-			log.Printf("Skipping block: project '%s' requires ticket", entryMap["project-alias"])
+			log.Print(i18n.T("Skipping block: project '%s' requires ticket", entryMap["project-alias"]))
 			continue
 		}
 
 		parsedHours, err := parseDurationString(entryMap["hours"])
 		if err != nil {
-			log.Printf("Skipping block due to invalid hours: %v", err)
+			log.Print(i18n.T("Skipping block due to invalid hours: %v", err))
 			continue
 		}
 
-		entryDate := time.Now().Format("2006-01-02")
-		if entryMap["date"] != "" {
-			if _, err := time.Parse("2006-01-02", entryMap["date"]); err == nil {
-				entryDate = entryMap["date"]
-			} else {
-				log.Printf("Invalid date, using today instead: %v", err)
-			}
-		}
-
 		timesheetTypeKey := "N"
 		if key, ok := types.TimesheetTypeLookup[entryMap["type"]]; ok {
 			timesheetTypeKey = key
@@ -327,7 +407,7 @@ func processEditorFile(profile config.Profile, client *mantis.Client, userID int
 			SalesOrderLine: projectInfo.SalesOrderLine,
 		}
 
-		fmt.Printf("Creating appointment: %+v\n", timesheetEntry)
+		fmt.Println(i18n.T("Creating appointment: %+v", timesheetEntry))
 		appoint(client, userID, timesheetEntry, ctx)
 	}
 }
@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+)
+
+// maxExtractBytes bounds what a single archive may expand to. It is a
+// variable so tests can lower it.
+var maxExtractBytes int64 = 2 << 30
+
+type savedAttachment struct {
+	Path string
+	// Skipped is set when a file with the same content was already present.
+	Skipped bool
+}
+
+// filterAttachments keeps the attachments whose name matches the glob,
+// case-insensitively. An empty glob keeps everything.
+func filterAttachments(atts []mantis.Attachment, glob string) ([]mantis.Attachment, error) {
+	if glob == "" {
+		return atts, nil
+	}
+	glob = strings.ToLower(glob)
+	if _, err := filepath.Match(glob, ""); err != nil {
+		return nil, i18n.Errorf("invalid --match %q: %w", glob, err)
+	}
+
+	var out []mantis.Attachment
+	for _, a := range atts {
+		if ok, _ := filepath.Match(glob, strings.ToLower(a.FileName)); ok {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func printAttachments(atts []mantis.Attachment) {
+	if len(atts) == 0 {
+		fmt.Println(i18n.T("No attachments."))
+		return
+	}
+	for _, a := range atts {
+		fmt.Printf("%s  %s",
+			utils.MutedStyle.Sprint(a.CreatedAt.Local().Format("2006-01-02 15:04")), a.FileName)
+		if a.CreatedBy.Name != "" {
+			utils.MutedStyle.Print(i18n.T("  by %s", a.CreatedBy.Name))
+		}
+		fmt.Println()
+	}
+}
+
+// downloadAttachments saves the attachments into dir, optionally extracting
+// archives. Failures are reported per file.
+func downloadAttachments(atts []mantis.Attachment, dir string, extract bool) {
+	for _, att := range atts {
+		fmt.Println(i18n.T("Downloading attachment: %s", att.FileName))
+		file, err := mantisClient.Dashboard.GetSupportFile(mantisCtx, att)
+		if err != nil {
+			fmt.Println(i18n.T("Error trying to download attachment [%s]: %v", att.FileName, err))
+			continue
+		}
+
+		saved, err := saveAttachment(dir, att.FileName, file.FileContent)
+		if err != nil {
+			fmt.Println(i18n.T("Error saving attachment [%s]: %v", att.FileName, err))
+			continue
+		}
+		if saved.Skipped {
+			utils.MutedStyle.Println(i18n.T("Already present: %s", saved.Path))
+		} else {
+			fmt.Println(i18n.T("Saved: %s", saved.Path))
+		}
+
+		if !extract || !isArchive(saved.Path) {
+			continue
+		}
+		dest := strings.TrimSuffix(saved.Path, archiveExt(saved.Path))
+		n, err := extractArchive(saved.Path, dest)
+		if err != nil {
+			fmt.Println(i18n.T("Error extracting [%s]: %v", saved.Path, err))
+		}
+		if n > 0 {
+			fmt.Println(i18n.T("Extracted %d file(s) into %s", n, dest))
+		}
+	}
+}
+
+// saveAttachment decodes content into dir under the sanitized name. When a
+// file with the same content already exists in dir nothing is written;
+// when the name is taken by a different file a " (n)" suffix is added.
+func saveAttachment(dir, name, content string) (savedAttachment, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return savedAttachment{}, err
+	}
+
+	tmp, err := createDownloadTemp(dir)
+	if err != nil {
+		return savedAttachment{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), decodeFileContent(content))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return savedAttachment{}, i18n.Errorf("decoding: %w", err)
+	}
+	sum := h.Sum(nil)
+
+	if dup, ok := findDuplicate(dir, size, sum); ok {
+		return savedAttachment{Path: dup, Skipped: true}, nil
+	}
+
+	name = utils.SanitizeFileName(name)
+	for i := 0; ; i++ {
+		path := filepath.Join(dir, numberedName(name, i))
+		// Link fails instead of replacing a file created meanwhile.
+		err := os.Link(tmp.Name(), path)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return savedAttachment{}, err
+		}
+		return savedAttachment{Path: path}, nil
+	}
+}
+
+// createDownloadTemp is os.CreateTemp with mode 0644 instead of 0600, so
+// the file keeps the usual permissions (less the umask) once linked.
+func createDownloadTemp(dir string) (*os.File, error) {
+	for {
+		name := filepath.Join(dir, fmt.Sprintf(".intracli-download-%d", rand.Uint32()))
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		return f, err
+	}
+}
+
+// numberedName returns "name (i).ext", or name itself for i == 0.
+func numberedName(name string, i int) string {
+	if i == 0 {
+		return name
+	}
+	ext := archiveExt(name)
+	if ext == "" {
+		ext = filepath.Ext(name)
+	}
+	return fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), i, ext)
+}
+
+// findDuplicate looks in dir for a regular file with the given size and
+// SHA-256.
+func findDuplicate(dir string, size int64, sum []byte) (string, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+	for _, e := range entries {
+		if !e.Type().IsRegular() || strings.HasPrefix(e.Name(), ".intracli-download-") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || info.Size() != size {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if other, err := fileHash(path); err == nil && bytes.Equal(other, sum) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func fileHash(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// decodeFileContent streams the attachment content, which Mantis sends hex
+// or base64 encoded, possibly wrapped and with the padding stripped.
+func decodeFileContent(s string) io.Reader {
+	n, isHex, urlSafe := 0, true, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isSpace(c) {
+			continue
+		}
+		n++
+		switch {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+		case c == '-' || c == '_':
+			urlSafe, isHex = true, false
+		default:
+			isHex = false
+		}
+	}
+
+	src := &skipSpaceReader{s: s}
+	if isHex && n%2 == 0 {
+		return hex.NewDecoder(src)
+	}
+
+	enc := base64.StdEncoding
+	if urlSafe {
+		enc = base64.URLEncoding
+	}
+	var r io.Reader = src
+	if m := n % 4; m != 0 {
+		r = io.MultiReader(src, strings.NewReader(strings.Repeat("=", 4-m)))
+	}
+	return base64.NewDecoder(enc, r)
+}
+
+// skipSpaceReader reads a string leaving out whitespace.
+type skipSpaceReader struct {
+	s string
+	i int
+}
+
+func (r *skipSpaceReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) && r.i < len(r.s) {
+		if c := r.s[r.i]; !isSpace(c) {
+			p[n] = c
+			n++
+		}
+		r.i++
+	}
+	if n == 0 && r.i >= len(r.s) {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t'
+}
+
+// archiveExt returns the archive extension of name, including compound ones
+// such as .tar.gz, or "" when it is not an archive.
+func archiveExt(name string) string {
+	lower := strings.ToLower(name)
+	for _, ext := range []string{".tar.gz", ".tgz", ".tar", ".zip"} {
+		if strings.HasSuffix(lower, ext) {
+			return name[len(name)-len(ext):]
+		}
+	}
+	return ""
+}
+
+func isArchive(name string) bool {
+	return archiveExt(name) != ""
+}
+
+// extractArchive unpacks a zip or tar(.gz) archive into dest. Entry paths are
+// sanitized segment by segment, absolute and ../ entries are skipped so
+// nothing is written outside dest, links and special files are skipped, and
+// existing files are left untouched.
+func extractArchive(path, dest string) (int, error) {
+	budget := maxExtractBytes
+	count := 0
+
+	write := func(name string, r io.Reader) error {
+		target, ok := archiveEntryPath(dest, name)
+		if !ok {
+			utils.ErrorStyle.Println(i18n.T("Skipping unsafe archive entry %q", name))
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, os.ErrExist) {
+			utils.MutedStyle.Println(i18n.T("Already present: %s", target))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		n, err := io.Copy(f, io.LimitReader(r, budget+1))
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		if budget -= n; budget < 0 {
+			os.Remove(target)
+			return i18n.Errorf("archive expands beyond %d bytes", maxExtractBytes)
+		}
+		count++
+		return nil
+	}
+
+	if strings.EqualFold(archiveExt(path), ".zip") {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			return 0, err
+		}
+		defer zr.Close()
+
+		for _, zf := range zr.File {
+			if !zf.Mode().IsRegular() {
+				continue
+			}
+			rc, err := zf.Open()
+			if err != nil {
+				return count, err
+			}
+			err = write(zf.Name, rc)
+			rc.Close()
+			if err != nil {
+				return count, err
+			}
+		}
+		return count, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if ext := strings.ToLower(archiveExt(path)); ext == ".tar.gz" || ext == ".tgz" {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return 0, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := write(hdr.Name, tr); err != nil {
+			return count, err
+		}
+	}
+}
+
+// archiveEntryPath maps an archive entry name to a path under dest. Absolute
+// names, drive letters and .. segments are rejected.
+func archiveEntryPath(dest, name string) (string, bool) {
+	if strings.HasPrefix(name, "/") || strings.HasPrefix(name, `\`) || (len(name) > 1 && name[1] == ':') {
+		return "", false
+	}
+	var parts []string
+	for _, seg := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if seg == ".." {
+			return "", false
+		}
+		if seg == "." {
+			continue
+		}
+		parts = append(parts, utils.SanitizeFileName(seg))
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return filepath.Join(append([]string{dest}, parts...)...), true
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArchiveEntryPath(t *testing.T) {
+	dest := filepath.Join("tmp", "out")
+	tests := []struct {
+		name  string
+		entry string
+		want  string
+		ok    bool
+	}{
+		{"plain", "a.txt", filepath.Join(dest, "a.txt"), true},
+		{"nested", "dir/sub/a.txt", filepath.Join(dest, "dir", "sub", "a.txt"), true},
+		{"backslashes", `dir\a.txt`, filepath.Join(dest, "dir", "a.txt"), true},
+		{"dot segments", "./dir/./a.txt", filepath.Join(dest, "dir", "a.txt"), true},
+		{"sanitized segment", "dir/a:b.txt", filepath.Join(dest, "dir", "a_b.txt"), true},
+		{"parent", "../a.txt", "", false},
+		{"parent in the middle", "dir/../../a.txt", "", false},
+		{"windows parent", `..\a.txt`, "", false},
+		{"absolute", "/etc/passwd", "", false},
+		{"windows absolute", `\Windows\win.ini`, "", false},
+		{"drive letter", `C:\Windows\win.ini`, "", false},
+		{"empty", "", "", false},
+		{"only dots", "./.", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := archiveEntryPath(dest, tt.entry)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("archiveEntryPath(%q) = %q, %v, want %q, %v", tt.entry, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestExtractArchive(t *testing.T) {
+	tests := []struct {
+		name      string
+		archive   string
+		entries   map[string]string
+		budget    int64
+		wantFiles []string
+		wantErr   bool
+	}{
+		{
+			name:      "zip",
+			archive:   "a.zip",
+			entries:   map[string]string{"dir/a.txt": "a", "b.txt": "b"},
+			wantFiles: []string{"dir/a.txt", "b.txt"},
+		},
+		{
+			name:      "zip with traversal and absolute entries",
+			archive:   "evil.zip",
+			entries:   map[string]string{"../evil.txt": "x", "/abs.txt": "x", "ok.txt": "ok"},
+			wantFiles: []string{"ok.txt"},
+		},
+		{
+			name:      "tar with traversal entry",
+			archive:   "evil.tar",
+			entries:   map[string]string{"../../evil.txt": "x", "ok.txt": "ok"},
+			wantFiles: []string{"ok.txt"},
+		},
+		{
+			name:    "zip over the budget",
+			archive: "big.zip",
+			entries: map[string]string{"big.bin": strings.Repeat("0", 2048)},
+			budget:  1024,
+			wantErr: true,
+		},
+		{
+			name:    "tar over the budget across entries",
+			archive: "big.tar",
+			entries: map[string]string{"a.bin": strings.Repeat("0", 600), "b.bin": strings.Repeat("0", 600)},
+			budget:  1024,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.budget > 0 {
+				defer func(old int64) { maxExtractBytes = old }(maxExtractBytes)
+				maxExtractBytes = tt.budget
+			}
+
+			root := t.TempDir()
+			path := filepath.Join(root, tt.archive)
+			writeTestArchive(t, path, tt.entries)
+			dest := filepath.Join(root, "out")
+
+			n, err := extractArchive(path, dest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractArchive error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if n != len(tt.wantFiles) {
+				t.Errorf("extracted %d file(s), want %d", n, len(tt.wantFiles))
+			}
+			for _, f := range tt.wantFiles {
+				if _, err := os.Stat(filepath.Join(dest, filepath.FromSlash(f))); err != nil {
+					t.Errorf("missing %s: %v", f, err)
+				}
+			}
+			for _, f := range []string{"evil.txt", "abs.txt"} {
+				if _, err := os.Stat(filepath.Join(root, f)); err == nil {
+					t.Errorf("%s was written outside dest", f)
+				}
+			}
+		})
+	}
+}
+
+func writeTestArchive(t *testing.T, path string, entries map[string]string) {
+	t.Helper()
+	var buf bytes.Buffer
+	if strings.HasSuffix(path, ".zip") {
+		zw := zip.NewWriter(&buf)
+		for name, body := range entries {
+			w, err := zw.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.Write([]byte(body))
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		tw := tar.NewWriter(&buf)
+		for name, body := range entries {
+			hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(body)), Typeflag: tar.TypeReg}
+			if err := tw.WriteHeader(hdr); err != nil {
+				t.Fatal(err)
+			}
+			tw.Write([]byte(body))
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
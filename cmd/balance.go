@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+var (
+	balanceFrom    string
+	balanceTo      string
+	balanceOpening float64
+	balanceForce   bool
+)
+
+func init() {
+	balanceCmd.Flags().StringVar(&balanceFrom, "from", "", "Start date (YYYY-MM-DD or token; default latest opening balance)")
+	balanceCmd.Flags().StringVar(&balanceTo, "to", "yesterday", "End date (YYYY-MM-DD or token)")
+	balanceCmd.Flags().Float64Var(&balanceOpening, "opening", 0, "Opening balance in hours (overrides the profile's openingBalances)")
+	balanceCmd.Flags().BoolVarP(&balanceForce, "force", "f", false, "Force refresh instead of reading cached months")
+
+	rootCmd.AddCommand(balanceCmd)
+}
+
+// balanceCmd computes the hour bank: hours logged minus the journey expected
+// on every business day.
+//
+// Profile settings (config.yaml):
+//
+//	openingBalances:
+//	  - date: 2026-01-01
+//	    hours: 12.5
+//	journeyOverrides:
+//	  - from: 2026-07-01
+//	    to: 2026-07-31
+//	    hours: 4
+var balanceCmd = &cobra.Command{
+	Use:   "balance",
+	Short: "Show your hour-bank balance as a weekly ledger",
+	Long: `Computes the running surplus or deficit of logged hours against your
+daily journey. Only business days are expected to be worked: weekends and
+the non-business days from the Mantis calendar are skipped, while hours
+logged on them still count.
+
+The ledger starts at the latest opening balance (profile 'openingBalances')
+dated on or before --from. Use 'journeyOverrides' for part-time periods.
+
+Examples:
+  intracli balance --from 2026-01-01
+  intracli balance --from this-year --opening 6
+  intracli balance --from last-month --to today`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := getCurrentProfile(appConfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		to, err := parseRangeDate(balanceTo)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var from time.Time
+		if balanceFrom != "" {
+			if from, err = parseRangeDate(balanceFrom); err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			ob, ok := utils.OpeningBalanceFor(profile, to)
+			if !ok {
+				log.Fatal(i18n.T("No --from given and the profile has no openingBalances."))
+			}
+			from, _ = parseRangeDate(ob.Date)
+		}
+
+		start, opening := from, 0.0
+		if ob, ok := utils.OpeningBalanceFor(profile, from); ok {
+			if start, err = parseRangeDate(ob.Date); err != nil {
+				log.Fatal(i18n.T("Invalid opening balance date %q: %v", ob.Date, err))
+			}
+			opening = ob.Hours
+		}
+		if cmd.Flags().Changed("opening") {
+			start, opening = from, balanceOpening
+		}
+
+		if start.After(to) {
+			log.Fatal(i18n.T("Invalid range: %s is after %s", start.Format("2006-01-02"), to.Format("2006-01-02")))
+		}
+
+		r := dateRange{From: start, To: to}
+		fetcher := newMonthFetcher(balanceForce)
+
+		timesheets, err := fetchTimesheetsInRange(mantisCtx, r, balanceForce)
+		if err != nil {
+			log.Fatal(i18n.T("Error getting timesheets: %v", err))
+		}
+
+		nbByMonth, err := fetcher.NonBusinessDays(mantisCtx, r.calendarMonths())
+		if err != nil {
+			log.Fatal(i18n.T("Error getting non-business days: %v", err))
+		}
+
+		weeks, total := utils.ComputeBalance(
+			timesheets, nonBusinessSet(nbByMonth), profile, start, to, opening,
+		)
+
+		fmt.Println(i18n.T("Hour bank for %s, %s", profile.EmployeeName, r))
+		if start.Before(from) {
+			fmt.Println(i18n.T("Opening balance %s on %s", formatBalance(opening), start.Format("2006-01-02")))
+		} else {
+			fmt.Println(i18n.T("Opening balance %s", formatBalance(opening)))
+		}
+		fmt.Println()
+
+		colorCfg := renderer.ColorizedConfig{
+			Header: renderer.Tint{FG: renderer.Colors{color.FgHiWhite, color.Bold}},
+		}
+		table := tablewriter.NewTable(os.Stdout,
+			tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+			tablewriter.WithConfig(tablewriter.Config{
+				Row: tw.CellConfig{
+					Formatting: tw.CellFormatting{AutoWrap: tw.WrapNone},
+					Alignment:  tw.CellAlignment{Global: tw.AlignLeft},
+				},
+			}),
+		)
+		table.Header(i18n.T("WEEK"), i18n.T("FROM"), i18n.T("TO"), i18n.T("EXPECTED"), i18n.T("WORKED"), i18n.T("DELTA"), i18n.T("BALANCE"))
+
+		fromKey := from.Format("2006-01-02")
+		for _, w := range weeks {
+			if w.End < fromKey {
+				continue
+			}
+			table.Append([]any{
+				fmt.Sprintf("%d-W%02d", w.Year, w.Week),
+				w.Start,
+				w.End,
+				i18n.Float(w.Expected, 2),
+				i18n.Float(w.Worked, 2),
+				formatBalance(w.Delta),
+				formatBalance(w.Balance),
+			})
+		}
+		table.Render()
+
+		fmt.Println()
+		fmt.Println(i18n.T("CURRENT BALANCE: %s", formatBalance(total)))
+	},
+}
+
+// formatBalance renders signed hours, green for surplus and red for deficit.
+func formatBalance(hours float64) string {
+	switch {
+	case utils.ApproxEqual(hours, 0, 0.001):
+		return i18n.Hours(0)
+	case hours > 0:
+		return utils.SuccessStyle.Sprint("+" + i18n.Hours(hours))
+	default:
+		return utils.ErrorStyle.Sprint(i18n.Hours(hours))
+	}
+}
+
+// nonBusinessSet flattens per-month non-business days into a set keyed by
+// YYYY-MM-DD.
+func nonBusinessSet(byMonth map[YearMonth][]mantis.NonBusinessDay) map[string]bool {
+	set := map[string]bool{}
+	for _, days := range byMonth {
+		for _, d := range days {
+			set[d.Date.Format("2006-01-02")] = true
+		}
+	}
+	return set
+}
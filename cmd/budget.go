@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultBudgetThreshold = 8.0
+	budgetHistoryRows      = 10
+	budgetBarWidth         = 30
+)
+
+var (
+	budgetAdd       []string
+	budgetRemove    []string
+	budgetList      bool
+	budgetNoPoll    bool
+	budgetThreshold float64
+)
+
+func init() {
+	budgetCmd.Flags().StringSliceVar(&budgetAdd, "add", nil, "Add tickets to the watchlist")
+	budgetCmd.Flags().StringSliceVar(&budgetRemove, "remove", nil, "Remove tickets from the watchlist")
+	budgetCmd.Flags().BoolVar(&budgetList, "list", false, "List watched tickets")
+	budgetCmd.Flags().BoolVar(&budgetNoPoll, "no-poll", false, "Render the stored history without polling Mantis")
+	budgetCmd.Flags().Float64Var(&budgetThreshold, "threshold", 0, "Flag tickets with fewer remaining hours (default profile budgetThreshold, or 8)")
+
+	budgetCmd.RegisterFlagCompletionFunc("add", ticketCompletionFunc)
+	budgetCmd.RegisterFlagCompletionFunc("remove", watchedTicketCompletionFunc)
+
+	rootCmd.AddCommand(budgetCmd)
+}
+
+// BudgetSnapshot is one reading of a ticket's project hours.
+type BudgetSnapshot struct {
+	Time     time.Time `json:"time"`
+	Approved float64   `json:"approved"`
+	Consumed float64   `json:"consumed"`
+}
+
+func (s BudgetSnapshot) Remaining() float64 {
+	return s.Approved - s.Consumed
+}
+
+var budgetCmd = &cobra.Command{
+	Use:   "budget [ticket...]",
+	Short: "Track the hour budget burn-down of watched tickets",
+	Long: `Polls the approved and consumed project hours of the watched tickets
+(or of the tickets given as arguments), stores a snapshot history locally and
+renders a burn-down with the consumption rate and a projected exhaustion
+date. Tickets whose remaining hours fall below the threshold are flagged.
+
+Examples:
+  intracli budget --add 8000123 --add 8000456
+  intracli budget
+  intracli budget 8000123 --threshold 16
+  intracli budget --remove 8000456`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := getCurrentProfile(appConfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if len(budgetAdd) > 0 || len(budgetRemove) > 0 {
+			for _, t := range budgetAdd {
+				if !slices.Contains(profile.WatchedTickets, t) {
+					profile.WatchedTickets = append(profile.WatchedTickets, t)
+				}
+			}
+			profile.WatchedTickets = slices.DeleteFunc(profile.WatchedTickets, func(t string) bool {
+				return slices.Contains(budgetRemove, t)
+			})
+			if err := saveCurrentProfile(profile); err != nil {
+				log.Fatal(i18n.T("Failed to save config: %v", err))
+			}
+			fmt.Println(i18n.T("Watching %d ticket(s).", len(profile.WatchedTickets)))
+			return
+		}
+
+		if budgetList {
+			if len(profile.WatchedTickets) == 0 {
+				fmt.Println(i18n.T("No watched tickets."))
+				return
+			}
+			fmt.Println(i18n.T("Watched tickets:"))
+			for _, t := range profile.WatchedTickets {
+				fmt.Printf("  %s\n", t)
+			}
+			return
+		}
+
+		tickets := args
+		if len(tickets) == 0 {
+			tickets = profile.WatchedTickets
+		}
+		if len(tickets) == 0 {
+			fmt.Println(i18n.T("No watched tickets. Use --add <ticket> or pass tickets as arguments."))
+			return
+		}
+
+		threshold := budgetThreshold
+		if threshold <= 0 {
+			threshold = profile.BudgetThreshold
+		}
+		if threshold <= 0 {
+			threshold = defaultBudgetThreshold
+		}
+
+		for i, t := range tickets {
+			if i > 0 {
+				fmt.Println()
+			}
+
+			history, _ := cache.ReadFromCache[BudgetSnapshot](budgetCacheKey(t))
+			if !budgetNoPoll {
+				history, err = pollBudget(t, history)
+				if err != nil {
+					utils.ErrorStyle.Println(i18n.T("Ticket %s: %v", t, err))
+					continue
+				}
+			}
+			renderBudget(t, history, threshold)
+		}
+	},
+}
+
+// pollBudget reads the current project hours of a ticket and appends them to
+// its history when they changed since the last snapshot.
+func pollBudget(ticketNo string, history []BudgetSnapshot) ([]BudgetSnapshot, error) {
+	resp, err := fetchSupportInfo(ticketNo)
+	if err != nil {
+		return history, err
+	}
+	if resp.TotHrAprovadaPC == "" {
+		return history, i18n.Errorf("no project hour budget")
+	}
+
+	approved, err := parseHours(resp.TotHrAprovadaPC)
+	if err != nil {
+		return history, i18n.Errorf("approved hours: %w", err)
+	}
+	consumed, err := parseHours(resp.TotHrPC)
+	if err != nil {
+		return history, i18n.Errorf("consumed hours: %w", err)
+	}
+	snap := BudgetSnapshot{
+		Time:     time.Now(),
+		Approved: approved,
+		Consumed: consumed,
+	}
+
+	if n := len(history); n == 0 ||
+		history[n-1].Approved != snap.Approved ||
+		history[n-1].Consumed != snap.Consumed {
+		history = append(history, snap)
+		if err := cache.WriteToCache(budgetCacheKey(ticketNo), history); err != nil {
+			log.Print(i18n.T("Warning: failed to write budget history: %v", err))
+		}
+	}
+	return history, nil
+}
+
+func renderBudget(ticketNo string, history []BudgetSnapshot, threshold float64) {
+	if len(history) == 0 {
+		utils.MutedStyle.Println(i18n.T("Ticket %s: no history yet", ticketNo))
+		return
+	}
+
+	last := history[len(history)-1]
+	utils.TitleStyle.Print(i18n.T("Ticket %s", ticketNo) + " ")
+	fmt.Println(i18n.T("approved %s  consumed %s  remaining %s",
+		i18n.Hours(last.Approved), i18n.Hours(last.Consumed), i18n.Hours(last.Remaining())))
+
+	start := max(0, len(history)-budgetHistoryRows)
+	for _, s := range history[start:] {
+		fraction := 0.0
+		if s.Approved > 0 {
+			fraction = s.Remaining() / s.Approved
+		}
+		fmt.Printf("  %s  %s  %s\n",
+			s.Time.Format("2006-01-02"), utils.Bar(fraction, budgetBarWidth),
+			i18n.T("%8s left", i18n.Hours(s.Remaining())))
+	}
+
+	rate := budgetRate(history, time.Now())
+	switch {
+	case rate <= 0:
+		utils.MutedStyle.Println("  " + i18n.T("no consumption recorded yet"))
+	case last.Remaining() <= 0:
+		fmt.Println("  " + i18n.T("rate %s/day · budget exhausted", i18n.Hours(rate)))
+	default:
+		days := last.Remaining() / rate
+		exhaustion := time.Now().Add(time.Duration(days * 24 * float64(time.Hour)))
+		fmt.Println("  " + i18n.T("rate %s/day · exhaustion ~%s (%d days)",
+			i18n.Hours(rate), exhaustion.Format("2006-01-02"), int(math.Ceil(days))))
+	}
+
+	if last.Remaining() < threshold {
+		utils.ErrorStyle.Println("  ⚠ " + i18n.T("%s left, below the %s threshold", i18n.Hours(last.Remaining()), i18n.Hours(threshold)))
+	}
+}
+
+// budgetRate is the average consumption in hours per day between the first
+// snapshot and now.
+func budgetRate(history []BudgetSnapshot, now time.Time) float64 {
+	if len(history) < 2 {
+		return 0
+	}
+	first, last := history[0], history[len(history)-1]
+	days := now.Sub(first.Time).Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+	return (last.Consumed - first.Consumed) / days
+}
+
+// parseHours parses Mantis hour strings. The last of comma and dot is the
+// decimal separator and the other one groups thousands, so both "1.234,5"
+// and "1,234.5" are 1234.5. An empty string is 0.
+func parseHours(raw string) (float64, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return 0, nil
+	}
+	comma, dot := strings.LastIndex(s, ","), strings.LastIndex(s, ".")
+	switch {
+	case comma > dot:
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.ReplaceAll(s, ",", ".")
+	case dot > comma:
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, i18n.Errorf("invalid hours %q", raw)
+	}
+	return v, nil
+}
+
+func budgetCacheKey(ticketNo string) string {
+	return fmt.Sprintf(cache.BudgetCacheFileName, ticketNo)
+}
+
+func watchedTicketCompletionFunc(
+	cmd *cobra.Command,
+	args []string,
+	toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.InitializeConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	profile, err := getCurrentProfile(cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var out []string
+	for _, t := range profile.WatchedTickets {
+		if strings.HasPrefix(t, toComplete) {
+			out = append(out, t)
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import "testing"
+
+func TestParseHours(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"  ", 0, false},
+		{"8", 8, false},
+		{"7,5", 7.5, false},
+		{"7.5", 7.5, false},
+		{" 12,25 ", 12.25, false},
+		{"1.234,5", 1234.5, false},
+		{"1,234.5", 1234.5, false},
+		{"1.234.567,25", 1234567.25, false},
+		{"1,234,567.25", 1234567.25, false},
+		{"abc", 0, true},
+		{"1,2,3", 0, true},
+		{"8h", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseHours(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseHours(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseHours(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/IntraCLI/utils"
 	"github.com/Salvadego/mantis/mantis"
 	"github.com/spf13/cobra"
@@ -25,6 +26,11 @@ type CalConfig struct {
 	NoColor  bool
 	Vertical bool
 	YearView bool
+	Heatmap  bool
+	Week     string // this-week, last-week or a date in the week
+	From     string
+	To       string
+	SVG      string // heatmap SVG output file, "-" for stdout
 }
 
 type YearMonth struct {
@@ -43,18 +49,9 @@ type DayInfo struct {
 
 var calCfg CalConfig
 
-var mesesLong = [...]string{
-	"Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho",
-	"Julho", "Agosto", "Setembro", "Outubro", "Novembro", "Dezembro",
-}
-
 const (
 	INVERT = "\033[7m"
 	BOLD   = "\033[1m"
-	GREEN  = "\033[32m"
-	RED    = "\033[31m"
-	CYAN   = "\033[36m"
-	BLUE   = "\033[34m"
 	RESET  = "\033[0m"
 )
 
@@ -77,12 +74,6 @@ func lerp(a, b RGB, t float64) RGB {
 	}
 }
 
-var (
-	colorRed    = hex2rgb("#ea6962")
-	colorYellow = hex2rgb("#e78a4e")
-	colorGreen  = hex2rgb("#a9b665")
-)
-
 type Renderer struct {
 	Padding  int
 	Monday   bool
@@ -95,7 +86,7 @@ func NewRenderer(cfg CalConfig) Renderer {
 	return Renderer{
 		Padding:  cfg.Padding,
 		Monday:   cfg.Monday,
-		NoColor:  cfg.NoColor,
+		NoColor:  cfg.NoColor || utils.NoColorRequested(),
 		Vertical: cfg.Vertical,
 		Now:      time.Now(),
 	}
@@ -105,19 +96,24 @@ func (r Renderer) colorForHours(journeyHours, dayHours float64) string {
 	if r.NoColor {
 		return ""
 	}
+	c := hoursRGB(journeyHours, dayHours)
+	return utils.RGBSGR(c.R, c.G, c.B)
+}
+
+// hoursRGB is the theme's gradient of a day's hours against the journey.
+func hoursRGB(journeyHours, dayHours float64) RGB {
 	if journeyHours <= 0 {
 		journeyHours = 8
 	}
 	h := math.Max(0, math.Min(dayHours, journeyHours))
 	t := h / journeyHours
 
-	var c RGB
+	stops := utils.ActiveTheme.Gradient
+	low, mid, high := hex2rgb(stops[0]), hex2rgb(stops[1]), hex2rgb(stops[2])
 	if t <= 0.5 {
-		c = lerp(colorRed, colorYellow, t/0.5)
-	} else {
-		c = lerp(colorYellow, colorGreen, (t-0.5)/0.5)
+		return lerp(low, mid, t/0.5)
 	}
-	return fmt.Sprintf("\033[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	return lerp(mid, high, (t-0.5)/0.5)
 }
 
 func (r Renderer) RenderMonth(year int, month time.Month, days []DayInfo, journeyHours float64) {
@@ -131,7 +127,7 @@ func (r Renderer) RenderMonth(year int, month time.Month, days []DayInfo, journe
 }
 
 func (r Renderer) printHeader(year int, month time.Month) {
-	title := fmt.Sprintf("%s %d", mesesLong[int(month)-1], year)
+	title := fmt.Sprintf("%s %d", i18n.Month(month), year)
 	cellWidth := 2 + r.Padding
 	totalWidth := 7 * cellWidth
 	if len(title) < totalWidth {
@@ -141,11 +137,21 @@ func (r Renderer) printHeader(year int, month time.Month) {
 	}
 }
 
-func (r Renderer) printWeekdays() {
-	labels := []string{"do", "se", "te", "qu", "qu", "se", "sá"}
-	if r.Monday {
-		labels = []string{"se", "te", "qu", "qu", "se", "sá", "do"}
+// weekdayLabels returns the weekday column labels in week order.
+func (r Renderer) weekdayLabels() []string {
+	labels := make([]string, 7)
+	for i := range labels {
+		d := time.Weekday(i)
+		if r.Monday {
+			d = time.Weekday((i + 1) % 7)
+		}
+		labels[i] = i18n.WeekdayShort(d)
 	}
+	return labels
+}
+
+func (r Renderer) printWeekdays() {
+	labels := r.weekdayLabels()
 	for _, l := range labels {
 		fmt.Printf("%-*s", 2+r.Padding, l)
 	}
@@ -185,10 +191,7 @@ func (r Renderer) renderVertical(days []DayInfo, journeyHours float64) {
 	}
 
 	cellWidth := 2 + r.Padding
-	labels := []string{"do", "se", "te", "qu", "qu", "se", "sá"}
-	if r.Monday {
-		labels = []string{"se", "te", "qu", "qu", "se", "sá", "do"}
-	}
+	labels := r.weekdayLabels()
 
 	rows := make([][]DayInfo, 7)
 	first := days[0].Date
@@ -228,21 +231,7 @@ func (r Renderer) formatDay(d DayInfo, journeyHours float64) string {
 		bold = ""
 	}
 
-	color := ""
-	if !r.NoColor {
-		switch {
-		case d.Hours > 0:
-			color = r.colorForHours(journeyHours, d.Hours)
-		case d.IsHoliday:
-			color = CYAN
-		case d.IsToday:
-			color = RED
-		case !d.IsWeekend && d.Date.Before(r.Now):
-			color = RED
-		case d.IsWeekend:
-			color = BLUE
-		}
-	}
+	color := r.dayColor(d, journeyHours)
 
 	cell := fmt.Sprintf("%02d", d.Date.Day())
 	if d.IsToday {
@@ -251,22 +240,44 @@ func (r Renderer) formatDay(d DayInfo, journeyHours float64) string {
 	return fmt.Sprintf("%s%s%s%s%s", bold, color, cell, reset, strings.Repeat(" ", r.Padding))
 }
 
+// dayColor is the color of a day cell: by hours logged, or by the kind of
+// day when nothing was logged.
+func (r Renderer) dayColor(d DayInfo, journeyHours float64) string {
+	if r.NoColor {
+		return ""
+	}
+	switch {
+	case d.Hours > 0:
+		return r.colorForHours(journeyHours, d.Hours)
+	case d.IsHoliday:
+		return utils.SGR(utils.ActiveTheme.Holiday)
+	case d.IsToday:
+		return utils.SGR(utils.ActiveTheme.Today)
+	case !d.IsWeekend && d.Date.Before(r.Now):
+		return utils.SGR(utils.ActiveTheme.Missing)
+	case d.IsWeekend:
+		return utils.SGR(utils.ActiveTheme.Weekend)
+	}
+	return ""
+}
+
 func (r Renderer) RenderDay(year int, month time.Month, day int, info DayInfo, nonBusiness map[int]mantis.NonBusinessDay) {
-	fmt.Printf("\n%s--- Apontamentos para %d de %s %d ---%s\n", BOLD, day, mesesLong[int(month)-1], year, RESET)
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	fmt.Printf("\n%s%s%s\n", BOLD, i18n.T("--- Appointments for %s ---", i18n.LongDate(date)), RESET)
 
 	if len(info.Appointments) == 0 {
 		if nbd, ok := nonBusiness[day]; ok {
-			fmt.Printf("%sDia não útil: %s%s\n", CYAN, nbd.Name, RESET)
+			fmt.Printf("%s%s%s\n", utils.SGR(utils.ActiveTheme.Holiday), i18n.T("Non-business day: %s", nbd.Name), RESET)
 		} else {
-			fmt.Printf("%sApontamentos não encontrados.%s\n", CYAN, RESET)
+			fmt.Printf("%s%s%s\n", utils.SGR(utils.ActiveTheme.Missing), i18n.T("No appointments found."), RESET)
 		}
 		return
 	}
 
-	fmt.Printf("%s%-8s %-40s %-10s%s\n", BOLD, "Hours", "Description", "Ticket", RESET)
+	fmt.Printf("%s%-8s %-40s %-10s%s\n", BOLD, i18n.T("Hours"), i18n.T("Description"), i18n.T("Ticket"), RESET)
 	fmt.Println(strings.Repeat("-", 70))
 	for _, ts := range info.Appointments {
-		fmt.Printf("%-8.2f %-40.40s %-10s\n", ts.Quantity, ts.Description, ts.TicketNo)
+		fmt.Printf("%-8s %-40.40s %-10s\n", i18n.Float(ts.Quantity, 2), ts.Description, ts.TicketNo)
 	}
 	fmt.Println(strings.Repeat("-", 70))
 }
@@ -314,6 +325,12 @@ func init() {
 	calCmd.Flags().BoolVarP(&calCfg.Force, "force", "f", false, "Force refresh")
 	calCmd.Flags().StringVarP(&calCfg.FilterName, "filter", "F", "", "Filter: raw qlvm query or @savedName")
 	calCmd.Flags().BoolVarP(&calCfg.YearView, "year-view", "y", false, "Show whole year")
+	calCmd.Flags().BoolVar(&calCfg.Heatmap, "heatmap", false, "Show the year as a contribution heatmap")
+	calCmd.Flags().StringVar(&calCfg.SVG, "svg", "", "Write the heatmap as SVG to this file (- for stdout)")
+	calCmd.Flags().StringVar(&calCfg.Week, "week", "", "Show a week: this-week, last-week or a date in it")
+	calCmd.Flags().Lookup("week").NoOptDefVal = "this-week"
+	calCmd.Flags().StringVar(&calCfg.From, "from", "", "Show the days from this date (YYYY-MM-DD or token)")
+	calCmd.Flags().StringVar(&calCfg.To, "to", "today", "With --from, show the days up to this date")
 
 	calCmd.Flags().IntVarP(&calCfg.Padding, "padding", "j", 1, "Horizontal padding")
 	calCmd.Flags().BoolVar(&calCfg.Monday, "monday", false, "Week starts on Monday")
@@ -338,56 +355,55 @@ var calCmd = &cobra.Command{
 		// Resolve the optional filter query string once up front.
 		filterQuery := resolveFilter(calCfg.FilterName, appConfig.SavedFilters)
 
-		if calCfg.YearView {
-			timesheetsByMonth := map[YearMonth][]mantis.TimesheetsResponse{}
-			nonBusinessByMonth := map[YearMonth]map[int]mantis.NonBusinessDay{}
+		if calCfg.Heatmap || calCfg.SVG != "" {
+			if err := runHeatmap(profile, r, filterQuery); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
 
+		if calCfg.Week != "" || calCfg.From != "" || cmd.Flags().Changed("to") {
+			rng, err := calRange(args)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if err := runCalRange(profile, r, filterQuery, rng); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		if calCfg.YearView {
+			var periods []YearMonth
 			for m := time.January; m <= time.December+1; m++ {
 				realMonth, realYear := m, calCfg.Year
 				if m > time.December {
 					realMonth, realYear = time.January, calCfg.Year+1
 				}
+				periods = append(periods, YearMonth{Year: realYear, Month: realMonth})
+			}
 
-				tsFilename := timesheetCacheKey(currentUserID, realYear, realMonth)
-				var ts []mantis.TimesheetsResponse
-				var err error
-
-				if !calCfg.Force {
-					ts, _ = cache.ReadFromCache[mantis.TimesheetsResponse](tsFilename)
-				}
-
-				if calCfg.Force || len(ts) == 0 {
-					ts, err = mantisClient.Timesheet.GetTimesheets(mantisCtx, currentUserID, realYear, realMonth)
-					if err != nil {
-						log.Fatalf("Error getting timesheets: %v", err)
-					}
-					if err := cache.WriteToCache(tsFilename, ts); err != nil {
-						log.Printf("Warning: failed to write timesheet cache (%s): %v", tsFilename, err)
-					}
-				}
-
-				ts = utils.ApplyFilter(ts, filterQuery, profile)
-
-				ym := YearMonth{Year: realYear, Month: realMonth}
-				timesheetsByMonth[ym] = ts
+			fetcher := newMonthFetcher(calCfg.Force)
+			timesheetsByMonth, err := fetcher.Timesheets(mantisCtx, periods)
+			if err != nil {
+				log.Fatal(i18n.T("Error getting timesheets: %v", err))
+			}
+			for ym, ts := range timesheetsByMonth {
+				timesheetsByMonth[ym] = utils.ApplyFilter(ts, filterQuery, profile)
+			}
 
-				nbFilename := nonBusinessCacheKey(realYear, realMonth)
-				var nbResp []mantis.NonBusinessDay
-				if !calCfg.Force {
-					nbResp, _ = cache.ReadFromCache[mantis.NonBusinessDay](nbFilename)
-				}
-				if calCfg.Force || len(nbResp) == 0 {
-					fetched, err := mantisClient.Calendar.GetNonBusinessDays(mantisCtx, realYear, realMonth)
-					if err != nil {
-						log.Printf("Warning: failed to get non-business days for %04d-%02d: %v", realYear, realMonth, err)
-					} else {
-						nbResp = fetched
-						_ = cache.WriteToCache(nbFilename, nbResp)
-					}
+			nbByMonth, err := fetcher.NonBusinessDays(mantisCtx, periods)
+			if err != nil {
+				if mantisCtx.Err() != nil {
+					log.Fatal(err)
 				}
+				log.Print(i18n.T("Warning: failed to get non-business days: %v", err))
+			}
 
+			nonBusinessByMonth := map[YearMonth]map[int]mantis.NonBusinessDay{}
+			for _, ym := range periods {
 				nbMap := map[int]mantis.NonBusinessDay{}
-				for _, d := range nbResp {
+				for _, d := range nbByMonth[ym] {
 					nbMap[d.Date.Day()] = d
 				}
 				nonBusinessByMonth[ym] = nbMap
@@ -524,7 +540,7 @@ func (r Renderer) RenderYear(year int, allDays map[time.Month][]DayInfo, journey
 		end := start + monthsPerRow - 1
 
 		for m := start; m <= end; m++ {
-			fmt.Printf("%-*s", monthWidth, center(mesesLong[m-1], monthWidth))
+			fmt.Printf("%-*s", monthWidth, center(i18n.Month(time.Month(m)), monthWidth))
 		}
 		fmt.Println()
 
@@ -557,10 +573,7 @@ func (r Renderer) RenderYear(year int, allDays map[time.Month][]DayInfo, journey
 }
 
 func (r Renderer) printWeekdaysInline(width int) {
-	labels := []string{"do", "se", "te", "qu", "qu", "se", "sá"}
-	if r.Monday {
-		labels = []string{"se", "te", "qu", "qu", "se", "sá", "do"}
-	}
+	labels := r.weekdayLabels()
 	cellWidth := 2 + r.Padding
 	var b strings.Builder
 	for _, l := range labels {
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/tui"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"golang.org/x/term"
+)
+
+// rangeBarWidth is the width of the day fill bar in the week view.
+const rangeBarWidth = 10
+
+// calRange resolves --week, --from and --to into the days to show. A
+// DATE given to --week selects the week containing it.
+func calRange(args []string) (dateRange, error) {
+	if calCfg.Week != "" {
+		week := calCfg.Week
+		// --week has an optional value, so "--week last-week" arrives as an
+		// argument.
+		if week == "this-week" && len(args) == 1 {
+			week = args[0]
+		}
+		day, err := parseRangeDate(week)
+		if err != nil {
+			return dateRange{}, err
+		}
+		start := day
+		for start.Weekday() != weekStartDay(calCfg.Monday) {
+			start = start.AddDate(0, 0, -1)
+		}
+		return dateRange{From: start, To: start.AddDate(0, 0, 6)}, nil
+	}
+
+	if calCfg.From == "" {
+		return dateRange{}, i18n.Errorf("--to needs --from")
+	}
+	from, err := parseRangeDate(calCfg.From)
+	if err != nil {
+		return dateRange{}, err
+	}
+	to, err := parseRangeDate(calCfg.To)
+	if err != nil {
+		return dateRange{}, err
+	}
+	if from.After(to) {
+		return dateRange{}, i18n.Errorf("invalid range: %s is after %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	return dateRange{From: from, To: to}, nil
+}
+
+func weekStartDay(monday bool) time.Weekday {
+	if monday {
+		return time.Monday
+	}
+	return time.Sunday
+}
+
+// runCalRange shows the days of rng in columns, a row of up to seven
+// columns per week.
+func runCalRange(profile config.Profile, r Renderer, filterQuery string, rng dateRange) error {
+	timesheets, err := fetchTimesheetsInRange(mantisCtx, rng, calCfg.Force)
+	if err != nil {
+		return i18n.Errorf("error getting timesheets: %w", err)
+	}
+	timesheets = utils.ApplyFilter(timesheets, filterQuery, profile)
+
+	var months []YearMonth
+	for m := time.Date(rng.From.Year(), rng.From.Month(), 1, 0, 0, 0, 0, time.Local); !m.After(rng.To); m = m.AddDate(0, 1, 0) {
+		months = append(months, YearMonth{Year: m.Year(), Month: m.Month()})
+	}
+	nbByMonth, err := newMonthFetcher(calCfg.Force).NonBusinessDays(mantisCtx, months)
+	if err != nil {
+		if mantisCtx.Err() != nil {
+			return err
+		}
+		log.Print(i18n.T("Warning: failed to get non-business days: %v", err))
+	}
+
+	hoursByDate := map[string]float64{}
+	dateAppointments := map[string][]mantis.TimesheetsResponse{}
+	for _, ts := range timesheets {
+		key := ts.DateDoc[:10]
+		hoursByDate[key] += ts.Quantity
+		dateAppointments[key] = append(dateAppointments[key], ts)
+	}
+
+	var days []DayInfo
+	for _, ym := range months {
+		nbMap := map[int]mantis.NonBusinessDay{}
+		for _, d := range nbByMonth[ym] {
+			nbMap[d.Date.Day()] = d
+		}
+		for _, d := range buildDays(ym.Year, ym.Month, hoursByDate, dateAppointments, nbMap, r.Now) {
+			if rng.contains(d.Date) {
+				days = append(days, d)
+			}
+		}
+	}
+
+	colW := 18
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		colW = max(14, min(26, w/7))
+	}
+
+	names := map[string]string{}
+	for _, ym := range months {
+		for _, d := range nbByMonth[ym] {
+			names[d.Date.Format("2006-01-02")] = d.Name
+		}
+	}
+
+	var total, expected float64
+	weekStart := weekStartDay(r.Monday)
+	for start := 0; start < len(days); {
+		end := start + 1
+		for end < len(days) && days[end].Date.Weekday() != weekStart {
+			end++
+		}
+		week := days[start:end]
+		hours, want := r.renderRangeWeek(week, names, profile, colW)
+		total += hours
+		expected += want
+		start = end
+	}
+
+	if len(days) > 7 {
+		utils.TitleStyle.Println(i18n.T("Total %s: %s of %s", rng, i18n.Hours(total), i18n.Hours(expected)))
+	}
+	return nil
+}
+
+// renderRangeWeek prints one row of day columns and the week total. It
+// returns the hours logged and the hours expected on business days.
+func (r Renderer) renderRangeWeek(days []DayInfo, holidays map[string]string, profile config.Profile, colW int) (float64, float64) {
+	columns := make([][]string, len(days))
+	height := 0
+	var hours, expected float64
+	for i, d := range days {
+		hours += d.Hours
+		journey := utils.JourneyFor(profile, d.Date)
+		if !d.IsWeekend && !d.IsHoliday {
+			expected += journey
+		}
+
+		head := i18n.WeekdayShort(d.Date.Weekday()) + " " + i18n.DayMonth(d.Date)
+		switch {
+		case r.NoColor:
+		case d.IsToday:
+			head = BOLD + INVERT + head + RESET
+		case d.IsHoliday:
+			head = utils.SGR(utils.ActiveTheme.Holiday) + head + RESET
+		default:
+			head = BOLD + head + RESET
+		}
+		col := []string{head, r.dayBar(d, journeyHoursOrDefault(journey)) + " " + i18n.Float(d.Hours, 1) + "h"}
+		if name := holidays[d.Date.Format("2006-01-02")]; name != "" {
+			if !r.NoColor {
+				name = utils.SGR(utils.ActiveTheme.Holiday) + name + RESET
+			}
+			col = append(col, name)
+		}
+		for _, ts := range d.Appointments {
+			ticket := ts.TicketNo
+			if ticket == "" {
+				ticket = "—"
+			}
+			project := projectAliasFor(profile, int(ts.SalesOrder), int(ts.SalesOrderLine))
+			if project == "" {
+				project = ts.ProjectName
+			}
+			col = append(col, i18n.Hours(ts.Quantity)+" "+ticket, utils.MutedStyle.Sprint("  "+project))
+		}
+		columns[i] = col
+		height = max(height, len(col))
+	}
+
+	for row := range height {
+		var line strings.Builder
+		for _, col := range columns {
+			cell := ""
+			if row < len(col) {
+				cell = col[row]
+			}
+			line.WriteString(tui.Pad(cell, colW-1) + " ")
+		}
+		fmt.Println(strings.TrimRight(line.String(), " "))
+	}
+
+	_, week := days[0].Date.ISOWeek()
+	utils.MutedStyle.Println(i18n.T("Week %d: %s of %s", week, i18n.Hours(hours), i18n.Hours(expected)))
+	fmt.Println()
+	return hours, expected
+}
+
+// dayBar is a stacked bar of the day's entries against the journey: each
+// entry alternates between two shades, and a trailing + marks overtime.
+func (r Renderer) dayBar(d DayInfo, journey float64) string {
+	var b strings.Builder
+	b.WriteString(r.colorForHours(journey, d.Hours))
+
+	used := 0.0
+	cells := 0
+	for i, ts := range d.Appointments {
+		used += ts.Quantity
+		upTo := min(rangeBarWidth, int(used/journey*rangeBarWidth+0.5))
+		glyph := "█"
+		if i%2 == 1 {
+			glyph = "▓"
+		}
+		for ; cells < upTo; cells++ {
+			b.WriteString(glyph)
+		}
+	}
+	if !r.NoColor {
+		b.WriteString(RESET)
+	}
+	b.WriteString(strings.Repeat("░", rangeBarWidth-cells))
+	if d.Hours > journey+0.001 {
+		b.WriteString("+")
+	}
+	return b.String()
+}
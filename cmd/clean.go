@@ -1,10 +1,10 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +16,7 @@ const (
 	Contracts   CleanFile = "contracts"
 	Tickets     CleanFile = "tickets"
 	NonBusiness CleanFile = "nonBusiness"
+	Search      CleanFile = "search"
 	All         CleanFile = "all"
 )
 
@@ -25,6 +26,7 @@ var cleanFileValues = []string{
 	string(Contracts),
 	string(Tickets),
 	string(NonBusiness),
+	string(Search),
 	string(All),
 }
 
@@ -87,6 +89,16 @@ func deleteByCleanType(f CleanFile) error {
 				return err
 			}
 		}
+	case Search:
+		for _, name := range []string{cache.SearchDocsCacheFileName, cache.SearchIndexCacheFileName} {
+			file, err := cache.GetCacheFilePath(name)
+			if err != nil {
+				return err
+			}
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
 	case Employee:
 		filepath, err := cache.GetCacheFilePath(cache.EmployeeListCacheFileName)
 		if err != nil {
@@ -105,14 +117,10 @@ func deleteByCleanType(f CleanFile) error {
 		}
 
 	case All:
-		filepath, err := cache.GetCacheDirPath()
-		if err != nil {
-			return err
-		}
-		return os.RemoveAll(filepath)
+		return cache.ClearCacheDir()
 
 	default:
-		return fmt.Errorf("Invalid Clean Type")
+		return i18n.Errorf("Invalid Clean Type")
 	}
 
 	return nil
@@ -123,7 +131,7 @@ var cleanCmd = &cobra.Command{
 	Short: "Clean cache files from the cache directory",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
-			return fmt.Errorf("missing clean type")
+			return i18n.Errorf("missing clean type")
 		}
 		return deleteByCleanType(CleanFile(args[0]))
 	},
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/spf13/cobra"
+)
+
+const defaultDailyHardCap = 10.0
+
+var (
+	closeCheckMonth  string
+	closeCheckMaxHrs float64
+)
+
+func init() {
+	closeCheckCmd.Flags().StringVar(&closeCheckMonth, "month", now.Format("2006-01"), "Timesheet period to check (YYYY-MM)")
+	closeCheckCmd.Flags().Float64Var(&closeCheckMaxHrs, "max-hours", 0, "Daily hard cap (default profile dailyHardCap, or 10)")
+
+	rootCmd.AddCommand(closeCheckCmd)
+}
+
+type closeProblem struct {
+	Date   string
+	Detail string
+}
+
+// closeCheck groups problems under a title, in the order they are printed.
+type closeCheck struct {
+	Title    string
+	Problems []closeProblem
+}
+
+var closeCheckCmd = &cobra.Command{
+	Use:   "close-check",
+	Short: "Check a timesheet period for problems before it closes",
+	Long: `Runs every closing check on a timesheet period (26th of the previous
+month to the 25th) and reports all problems at once:
+
+  - business days logged below the daily journey
+  - entries on weekends or non-business days
+  - entries on projects that need a ticket but have none
+  - duplicate entries
+  - days above the daily hard cap
+  - entries whose sales order line is no longer assigned to you
+
+Exits with status 1 when problems are found, so it can run from cron or a
+git hook.
+
+Examples:
+  intracli close-check
+  intracli close-check --month 2026-10 --max-hours 9`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := getCurrentProfile(appConfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		month, err := time.ParseInLocation("2006-01", closeCheckMonth, time.Local)
+		if err != nil {
+			log.Fatal(i18n.T("Invalid --month %q: expected YYYY-MM", closeCheckMonth))
+		}
+		period := YearMonth{Year: month.Year(), Month: month.Month()}
+		r := periodRange(period)
+
+		hardCap := closeCheckMaxHrs
+		if hardCap <= 0 {
+			hardCap = profile.DailyHardCap
+		}
+		if hardCap <= 0 {
+			hardCap = defaultDailyHardCap
+		}
+
+		fetcher := newMonthFetcher(true)
+		byPeriod, err := fetcher.Timesheets(mantisCtx, []YearMonth{period})
+		if err != nil {
+			log.Fatal(i18n.T("Error getting timesheets: %v", err))
+		}
+		timesheets := mergeTimesheets([][]mantis.TimesheetsResponse{byPeriod[period]}, r)
+
+		// The period spans two calendar months, e.g. Carnival on Feb 26-28
+		// falls in the March period.
+		nbByMonth, err := fetcher.NonBusinessDays(mantisCtx, r.calendarMonths())
+		if err != nil {
+			log.Fatal(i18n.T("Error getting non-business days: %v", err))
+		}
+
+		projects, err := mantisClient.Timesheet.GetProjectTimesheets(mantisCtx, currentUser.EmployeeCode)
+		if err != nil {
+			log.Fatal(i18n.T("Error getting projects: %v", err))
+		}
+
+		checks := runCloseChecks(timesheets, nonBusinessSet(nbByMonth), projects, profile, r, hardCap)
+
+		fmt.Println(i18n.T("Closing check for period %04d-%02d (%s)", period.Year, period.Month, r))
+
+		total := 0
+		for _, c := range checks {
+			fmt.Println()
+			if len(c.Problems) == 0 {
+				utils.SuccessStyle.Printf("✔ %s\n", c.Title)
+				continue
+			}
+			total += len(c.Problems)
+			utils.ErrorStyle.Printf("✘ %s ", c.Title)
+			utils.MutedStyle.Printf("(%d)\n", len(c.Problems))
+			for _, p := range c.Problems {
+				fmt.Printf("    %s  %s\n", p.Date, p.Detail)
+			}
+		}
+
+		fmt.Println()
+		if total > 0 {
+			utils.ErrorStyle.Println(i18n.T("%d problem(s) found.", total))
+			os.Exit(1)
+		}
+		utils.SuccessStyle.Println(i18n.T("Timesheet is ready to close."))
+	},
+}
+
+func runCloseChecks(
+	timesheets []mantis.TimesheetsResponse,
+	nonBusiness map[string]bool,
+	projects []mantis.ProjectTimesheet,
+	profile config.Profile,
+	r dateRange,
+	hardCap float64,
+) []closeCheck {
+	byDate := map[string][]mantis.TimesheetsResponse{}
+	for _, ts := range timesheets {
+		byDate[ts.DateDoc[:10]] = append(byDate[ts.DateDoc[:10]], ts)
+	}
+
+	type projectKey struct{ order, line int64 }
+	assigned := map[projectKey]mantis.ProjectTimesheet{}
+	for _, p := range projects {
+		assigned[projectKey{int64(p.ProjectNumber), int64(p.EmployeeLineNumber)}] = p
+	}
+
+	below := closeCheck{Title: i18n.T("Business days below journey")}
+	offDays := closeCheck{Title: i18n.T("Entries on weekends or non-business days")}
+	noTicket := closeCheck{Title: i18n.T("Entries missing a required ticket")}
+	duplicates := closeCheck{Title: i18n.T("Duplicate entries")}
+	overCap := closeCheck{Title: i18n.T("Days above the %s hard cap", i18n.Hours(hardCap))}
+	unassigned := closeCheck{Title: i18n.T("Entries on projects no longer assigned")}
+
+	// Days still ahead are not expected to be complete yet.
+	last := r.To
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if today.Before(last) {
+		last = today
+	}
+
+	for d := r.From; !d.After(r.To); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		entries := byDate[key]
+
+		hours := 0.0
+		for _, ts := range entries {
+			hours += ts.Quantity
+		}
+
+		businessDay := !isWeekendDay(d.Weekday()) && !nonBusiness[key]
+		journey := utils.JourneyFor(profile, d)
+
+		if businessDay && !d.After(last) && hours < journey && !utils.ApproxEqual(hours, journey, 0.001) {
+			below.Problems = append(below.Problems, closeProblem{
+				Date:   key,
+				Detail: i18n.T("%s of %s", i18n.Hours(hours), i18n.Hours(journey)),
+			})
+		}
+
+		if !businessDay && len(entries) > 0 {
+			detail := i18n.T("%s on a weekend", i18n.Hours(hours))
+			if nonBusiness[key] {
+				detail = i18n.T("%s on a non-business day", i18n.Hours(hours))
+			}
+			offDays.Problems = append(offDays.Problems, closeProblem{
+				Date:   key,
+				Detail: detail,
+			})
+		}
+
+		if hours > hardCap && !utils.ApproxEqual(hours, hardCap, 0.001) {
+			overCap.Problems = append(overCap.Problems, closeProblem{
+				Date:   key,
+				Detail: i18n.T("%s logged", i18n.Hours(hours)),
+			})
+		}
+
+		seen := map[string]int{}
+		for _, ts := range entries {
+			sig := fmt.Sprintf("%d|%d|%.2f|%s|%s|%s",
+				ts.SalesOrder, ts.SalesOrderLine, ts.Quantity, ts.TicketNo,
+				ts.TimesheetType, strings.ToLower(strings.TrimSpace(ts.Description)))
+			if first, ok := seen[sig]; ok {
+				duplicates.Problems = append(duplicates.Problems, closeProblem{
+					Date:   key,
+					Detail: i18n.T("#%d duplicates #%d: %s", ts.TimesheetID, first, describeEntry(ts)),
+				})
+				continue
+			}
+			seen[sig] = ts.TimesheetID
+		}
+	}
+
+	for _, ts := range timesheets {
+		if ts.SalesOrder == 0 {
+			continue
+		}
+		key := ts.DateDoc[:10]
+
+		p, ok := assigned[projectKey{ts.SalesOrder, ts.SalesOrderLine}]
+		if !ok {
+			unassigned.Problems = append(unassigned.Problems, closeProblem{
+				Date:   key,
+				Detail: fmt.Sprintf("#%d %d/%d %s", ts.TimesheetID, ts.SalesOrder, ts.SalesOrderLine, describeEntry(ts)),
+			})
+		}
+
+		needsTicket := p.ProjectNeedTicket
+		if alias := projectAliasFor(profile, int(ts.SalesOrder), int(ts.SalesOrderLine)); alias != "" {
+			needsTicket = needsTicket || profile.ProjectAliases[alias].NeedsTicket
+		}
+		if needsTicket && ts.TicketNo == "" {
+			noTicket.Problems = append(noTicket.Problems, closeProblem{
+				Date:   key,
+				Detail: fmt.Sprintf("#%d %s", ts.TimesheetID, describeEntry(ts)),
+			})
+		}
+	}
+
+	checks := []closeCheck{below, offDays, noTicket, duplicates, overCap, unassigned}
+	for _, c := range checks {
+		sort.SliceStable(c.Problems, func(i, j int) bool { return c.Problems[i].Date < c.Problems[j].Date })
+	}
+	return checks
+}
+
+func describeEntry(ts mantis.TimesheetsResponse) string {
+	desc := strings.ReplaceAll(ts.Description, "\n", " ")
+	if runes := []rune(desc); len(runes) > 50 {
+		desc = string(runes[:49]) + "…"
+	}
+	return fmt.Sprintf("(%s) %s [%s]", i18n.Hours(ts.Quantity), desc, ts.ProjectName)
+}
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Salvadego/IntraCLI/cache"
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/IntraCLI/types"
 	"github.com/Salvadego/mantis/mantis"
 	"github.com/spf13/cobra"
@@ -19,6 +21,7 @@ import (
 var (
 	mantisClient  *mantis.Client
 	mantisCtx     context.Context
+	stopSignals   context.CancelFunc
 	currentUser   mantis.Employee
 	currentUserID int
 	appConfig     *config.Config
@@ -29,7 +32,7 @@ func initCommonMantisClient(cmd *cobra.Command) error {
 
 	appConfig, err = config.InitializeConfig()
 	if err != nil {
-		fmt.Printf("Fatal error during config initialization: %v\n", err)
+		fmt.Println(i18n.T("Fatal error during config initialization: %v", err))
 		os.Exit(1)
 	}
 
@@ -39,6 +42,14 @@ func initCommonMantisClient(cmd *cobra.Command) error {
 		}
 	}
 
+	scopeCache(appConfig)
+	if err := applyLanguage(appConfig); err != nil {
+		return err
+	}
+	if err := applyTheme(appConfig); err != nil {
+		return err
+	}
+
 	currentProfileName := appConfig.DefaultProfile
 	if profileName != "" {
 		currentProfileName = profileName
@@ -46,8 +57,10 @@ func initCommonMantisClient(cmd *cobra.Command) error {
 
 	profile, profileExists := appConfig.Profiles[currentProfileName]
 
-	username := os.Getenv("MANTIS_USERNAME")
-	password := os.Getenv("MANTIS_PASSWORD")
+	username, password, err := profileCredentials(profile.CredentialRef)
+	if err != nil {
+		return i18n.Errorf("profile '%s': %w", currentProfileName, err)
+	}
 	if username == "" || password == "" {
 		u, p, err := promptCredentials()
 		if err != nil {
@@ -66,16 +79,17 @@ func initCommonMantisClient(cmd *cobra.Command) error {
 	}
 
 	clientConfig := &mantis.ClientConfig{
-		Language: "pt_BR",
-		BaseURL:  appConfig.BaseURL,
+		Language: mantisLanguage,
+		BaseURL:  appConfig.BaseURLFor(profile),
 	}
 
 	mantisClient = mantis.NewClient(authConfig, clientConfig)
-	mantisCtx = context.Background()
+	// Ctrl-C cancels in-flight requests instead of waiting for them.
+	mantisCtx = newSignalContext()
 
 	resp, err := mantisClient.Auth.Authenticate(mantisCtx)
 	if err != nil {
-		return fmt.Errorf("authentication failed: %w", err)
+		return i18n.Errorf("authentication failed: %w", err)
 	}
 
 	currentUserID = profile.UserID
@@ -87,12 +101,12 @@ func initCommonMantisClient(cmd *cobra.Command) error {
 		return nil
 	}
 
-	if appConfig.RoleID == 0 {
+	if roleID := appConfig.RoleIDFor(profile); roleID == 0 {
 		if err := handleMissingRoleID(appConfig); err != nil {
 			return err
 		}
 	} else {
-		mantisClient.SetRoleID(strconv.Itoa(appConfig.RoleID))
+		mantisClient.SetRoleID(strconv.Itoa(roleID))
 	}
 
 	if profileExists && profile.UserID != 0 {
@@ -101,7 +115,7 @@ func initCommonMantisClient(cmd *cobra.Command) error {
 			profile.UserID,
 		)
 		if err != nil {
-			return fmt.Errorf(
+			return i18n.Errorf(
 				"failed to get employee information for '%d': %w",
 				profile.UserID, err,
 			)
@@ -119,25 +133,63 @@ func initCommonMantisClient(cmd *cobra.Command) error {
 	return nil
 }
 
+// activeProfileName is the --profile name, or the default profile.
+func activeProfileName(cfg *config.Config) string {
+	if profileName != "" {
+		return profileName
+	}
+	return cfg.DefaultProfile
+}
+
+// profileCredentials reads the login named by a profile's credentialRef.
+// An empty ref reads MANTIS_USERNAME and MANTIS_PASSWORD, which may be
+// unset; the caller prompts then.
+func profileCredentials(ref string) (string, string, error) {
+	kind, arg, _ := strings.Cut(ref, ":")
+	switch {
+	case ref == "":
+		return os.Getenv("MANTIS_USERNAME"), os.Getenv("MANTIS_PASSWORD"), nil
+	case kind == "env" && arg != "":
+		username, password := os.Getenv(arg+"_USERNAME"), os.Getenv(arg+"_PASSWORD")
+		if username == "" || password == "" {
+			return "", "", i18n.Errorf("credentialRef %s: set %s_USERNAME and %s_PASSWORD", ref, arg, arg)
+		}
+		return username, password, nil
+	case kind == "cmd" && arg != "":
+		c := exec.Command("sh", "-c", arg)
+		c.Stdin, c.Stderr = os.Stdin, os.Stderr
+		out, err := c.Output()
+		if err != nil {
+			return "", "", i18n.Errorf("credentialRef command failed: %w", err)
+		}
+		lines := strings.SplitN(strings.ReplaceAll(string(out), "\r\n", "\n"), "\n", 3)
+		if len(lines) < 2 || lines[0] == "" || lines[1] == "" {
+			return "", "", i18n.Errorf("credentialRef command must print the username and the password on two lines")
+		}
+		return lines[0], lines[1], nil
+	}
+	return "", "", i18n.Errorf("invalid credentialRef %q: expected env:PREFIX or cmd:COMMAND", ref)
+}
+
 func promptCredentials() (string, string, error) {
 	var username, pwd string
 
-	fmt.Print("Mantis username: ")
+	fmt.Print(i18n.T("Mantis username: "))
 	fmt.Scanln(&username)
 
-	fmt.Print("Mantis password: ")
+	fmt.Print(i18n.T("Mantis password: "))
 	fmt.Scanln(&pwd)
 
 	fmt.Printf(`
-Set the following environment variables:
+%s
   export MANTIS_USERNAME=%s
   export MANTIS_PASSWORD=%s
 
-In your shellrc.
+%s
 	- bashrc
 	- zshrc
 	etc
-`, username, string(pwd))
+`, i18n.T("Set the following environment variables:"), username, string(pwd), i18n.T("In your shellrc."))
 
 	return username, string(pwd), nil
 }
@@ -149,8 +201,13 @@ func initTimesheetLookups(timesheetTypes []mantis.ReferenceType) {
 	}
 }
 
+// handleMissingRoleID asks for the role of the active profile and saves
+// it: on the profile when it has its own baseURL, since roles belong to a
+// Mantis instance, and globally otherwise.
 func handleMissingRoleID(appConfig *config.Config) error {
-	userID := appConfig.Profiles[appConfig.DefaultProfile].UserID
+	name := activeProfileName(appConfig)
+	profile := appConfig.Profiles[name]
+	userID := profile.UserID
 	if userID == 0 {
 		userID = currentUserID
 	}
@@ -167,9 +224,16 @@ func handleMissingRoleID(appConfig *config.Config) error {
 	roleId := strconv.Itoa(int(selectedRole.ADRoleID))
 	mantisClient.SetRoleID(roleId)
 
-	appConfig.RoleID = int(selectedRole.ADRoleID)
-	config.SaveConfig(appConfig)
-	fmt.Printf("Role set to: %s (ID: %s)\n", selectedRole.Name, roleId)
+	if profile.BaseURL != "" {
+		profile.RoleID = int(selectedRole.ADRoleID)
+		appConfig.Profiles[name] = profile
+	} else {
+		appConfig.RoleID = int(selectedRole.ADRoleID)
+	}
+	if err := config.SaveConfig(appConfig); err != nil {
+		return i18n.Errorf("saving role: %w", err)
+	}
+	fmt.Println(i18n.T("Role set to: %s (ID: %s)", selectedRole.Name, roleId))
 	return nil
 }
 
@@ -196,7 +260,7 @@ func projectAliasCompletionFunc(
 ) ([]string, cobra.ShellCompDirective) {
 	cfg, err := config.InitializeConfig()
 	if err != nil {
-		log.Printf("Error loading config for completion: %v", err)
+		log.Print(i18n.T("Error loading config for completion: %v", err))
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
@@ -207,10 +271,10 @@ func projectAliasCompletionFunc(
 
 	profile, ok := cfg.Profiles[currentProfileName]
 	if !ok {
-		log.Printf(
+		log.Print(i18n.T(
 			"Default profile '%s' not found for completion.",
 			cfg.DefaultProfile,
-		)
+		))
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
@@ -253,6 +317,21 @@ func filterDaysNameCompletionFunc(cmd *cobra.Command, args []string, toComplete
 	return out, cobra.ShellCompDirectiveNoFileComp
 }
 
+func filterTicketsNameCompletionFunc(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.InitializeConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	prefix := strings.TrimPrefix(toComplete, "@")
+	var out []string
+	for name, query := range cfg.SavedTicketFilters {
+		if strings.HasPrefix(name, prefix) {
+			out = append(out, fmt.Sprintf("@%s\t%s", name, query))
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
 func timesheetIdCompletionFunc(
 	cmd *cobra.Command,
 	args []string,
@@ -260,7 +339,7 @@ func timesheetIdCompletionFunc(
 ) ([]string, cobra.ShellCompDirective) {
 	cfg, err := config.InitializeConfig()
 	if err != nil {
-		log.Printf("Error loading config for completion: %v", err)
+		log.Print(i18n.T("Error loading config for completion: %v", err))
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
@@ -271,13 +350,14 @@ func timesheetIdCompletionFunc(
 
 	profile, ok := cfg.Profiles[currentProfileName]
 	if !ok {
-		log.Printf(
+		log.Print(i18n.T(
 			"Default profile '%s' not found for completion.",
 			cfg.DefaultProfile,
-		)
+		))
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
+	cache.SetScope(cacheScopeFor(cfg, profile))
 	filename := fmt.Sprintf(cache.TimesheetsCacheFileName, profile.UserID, now.Year(), now.Month())
 	timesheets, err := cache.ReadFromCache[mantis.TimesheetsResponse](filename)
 
@@ -292,9 +372,7 @@ func timesheetIdCompletionFunc(
 
 		parsedDate, err := time.Parse("2006-01-02T15:04:05Z", ts.DateDoc)
 		if err == nil {
-			mes := meses[int(parsedDate.Month())-1]
-			formattedDate := fmt.Sprintf("%d de %s", parsedDate.Day(), mes)
-			comment = fmt.Sprintf("(%.2f) %s [%s]", ts.Quantity, ts.Description, formattedDate)
+			comment = fmt.Sprintf("(%s) %s [%s]", i18n.Float(ts.Quantity, 2), ts.Description, i18n.ShortDate(parsedDate))
 		} else {
 			comment = ts.Description
 		}
@@ -315,19 +393,52 @@ func getCurrentProfile(cfg *config.Config) (config.Profile, error) {
 	}
 	p, ok := cfg.Profiles[name]
 	if !ok {
-		return config.Profile{}, fmt.Errorf("profile '%s' not found", name)
+		return config.Profile{}, i18n.Errorf("profile '%s' not found", name)
 	}
 	return p, nil
 }
 
+// projectAliasFor returns the profile alias of a sales order line, or ""
+// when none matches.
+func projectAliasFor(profile config.Profile, salesOrder, salesOrderLine int) string {
+	for alias, info := range profile.ProjectAliases {
+		if info.SalesOrder == salesOrder && info.SalesOrderLine == salesOrderLine {
+			return alias
+		}
+	}
+	return ""
+}
+
+// timesheetTypeName returns the name of a timesheet type key, or the key
+// itself when the lookup does not know it.
+func timesheetTypeName(key string) string {
+	if name, ok := types.TimesheetTypeInverseLookup[key]; ok {
+		return name
+	}
+	return key
+}
+
+// saveCurrentProfile writes profile back under the active profile name.
+func saveCurrentProfile(profile config.Profile) error {
+	name := appConfig.DefaultProfile
+	if profileName != "" {
+		name = profileName
+	}
+	appConfig.Profiles[name] = profile
+	return config.SaveConfig(appConfig)
+}
+
 func ticketCompletionFunc(
 	cmd *cobra.Command,
 	args []string,
 	toComplete string,
 ) ([]string, cobra.ShellCompDirective) {
+	if cfg, err := config.InitializeConfig(); err == nil {
+		scopeCache(cfg)
+	}
 	tickets, err := loadAndMergeCachedTickets()
 	if err != nil {
-		log.Printf("Error loading cached tickets: %v", err)
+		log.Print(i18n.T("Error loading cached tickets: %v", err))
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
@@ -346,6 +457,9 @@ func contracsCompletion(
 	args []string,
 	toComplete string,
 ) ([]string, cobra.ShellCompDirective) {
+	if cfg, err := config.InitializeConfig(); err == nil {
+		scopeCache(cfg)
+	}
 	contracts, err := cache.ReadFromCache[mantis.LtContract](cache.ContractsListCacheFileName)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
@@ -1,12 +1,12 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -38,7 +38,7 @@ var configCmd = &cobra.Command{
 		editorCmd.Stdout = os.Stdout
 		editorCmd.Stderr = os.Stderr
 		if err := editorCmd.Run(); err != nil {
-			return fmt.Errorf("Error opening editor: %v", err)
+			return i18n.Errorf("Error opening editor: %v", err)
 		}
 		return nil
 	},
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/mantis/mantis"
 	"github.com/spf13/cobra"
 )
@@ -25,11 +26,11 @@ func contractsToLines(contracts []mantis.LtContract) []string {
 func refreshContracts(ctx context.Context) []mantis.LtContract {
 	contracts, err := mantisClient.Dashboard.GetReportContracts(ctx)
 	if err != nil {
-		log.Fatalf("Error getting contracts: %v", err)
+		log.Fatal(i18n.T("Error getting contracts: %v", err))
 	}
 	err = cache.WriteToCache(cache.ContractsListCacheFileName, contracts)
 	if err != nil {
-		log.Fatalf("Failed to write to cache: %v", err)
+		log.Fatal(i18n.T("Failed to write to cache: %v", err))
 	}
 	return contracts
 }
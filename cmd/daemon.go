@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/notify"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonInterval time.Duration
+	daemonOnce     bool
+)
+
+func init() {
+	daemonCmd.Flags().DurationVar(&daemonInterval, "interval", 5*time.Minute, "Polling interval")
+	daemonCmd.Flags().BoolVar(&daemonOnce, "once", false, "Poll a single time and exit (for cron)")
+
+	rootCmd.AddCommand(daemonCmd)
+}
+
+// daemonCmd polls the ticket report and notifies about SLA drops and status
+// changes. It keeps its own snapshot, so running 'tickets watch' in between
+// does not swallow notifications.
+//
+// Config (config.yaml):
+//
+//	notifications:
+//	  sinks: [desktop, webhook]
+//	  webhook: http://localhost:9000/intracli
+//	  command: 'logger -t intracli "$INTRACLI_TITLE"'
+//	  slaThresholds: [80, 50, 20]
+//	  statuses: [Waiting for Support]
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Notify about SLA drops and status changes of your tickets",
+	Long: `Polls the dashboard report of the current profile and sends a
+notification when a ticket assigned to you drops below one of the configured
+SLA thresholds (default 50%) or changes to one of the configured statuses.
+
+Sinks are configured under 'notifications' in config.yaml:
+
+  bell     ring the terminal bell and print the notification (default)
+  desktop  show a desktop notification through notify-send
+  command  run a shell command; the notification is passed as JSON on stdin
+           and as INTRACLI_KIND, INTRACLI_TICKET, INTRACLI_TITLE and
+           INTRACLI_BODY
+  webhook  POST the notification as JSON
+
+The first poll only records a baseline.
+
+Examples:
+  intracli daemon
+  intracli daemon --interval 2m
+  intracli daemon --once`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := getCurrentProfile(appConfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		sinks, err := notify.FromConfig(appConfig.Notifications)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rules := notify.RulesFromConfig(appConfig.Notifications, profile.SUserID)
+
+		opts := newReportOptions("", "")
+		snapshotFile := fmt.Sprintf(cache.DaemonCacheFileName, opts.Signature())
+
+		if !daemonOnce {
+			utils.MutedStyle.Println(i18n.T("Polling tickets every %s. Press Ctrl+C to stop.", daemonInterval))
+		}
+
+		for {
+			poll, err := pollTicketChanges(opts, snapshotFile, nil)
+			switch {
+			case mantisCtx.Err() != nil:
+				return
+			case err != nil && daemonOnce:
+				log.Fatal(i18n.T("Error polling tickets: %v", err))
+			case err != nil:
+				utils.ErrorStyle.Printf("%s  %v\n", time.Now().Format("15:04"), err)
+			case poll.Baseline:
+				fmt.Println(i18n.T("%s  recorded a baseline of %d ticket(s)", time.Now().Format("15:04"), len(poll.Curr)))
+			default:
+				notifications := rules.Evaluate(poll.Prev, poll.Curr)
+				for _, n := range notifications {
+					fmt.Printf("%s  %s: %s\n", n.Time.Format("15:04"), n.Title, n.Body)
+				}
+				dispatchNotifications(sinks, notifications)
+			}
+
+			if daemonOnce {
+				return
+			}
+			select {
+			case <-mantisCtx.Done():
+				return
+			case <-time.After(daemonInterval):
+			}
+		}
+	},
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/IntraCLI/utils"
 	"github.com/Salvadego/mantis/mantis"
 	"github.com/fatih/color"
@@ -22,7 +23,10 @@ var (
 	dateDayFilterFlag string // --filter-day:        raw query or @name
 	calYear           int
 	calMonth          int
+	summaryYear       int
+	summaryMonth      int
 	minDailyHours     float64
+	dateForce         bool
 )
 
 func init() {
@@ -36,8 +40,9 @@ func init() {
 	dateSummaryCmd.RegisterFlagCompletionFunc("filter-timesheet", filterNameCompletionFunc)
 	dateSummaryCmd.RegisterFlagCompletionFunc("filter-day", filterDaysNameCompletionFunc)
 
-	dateSummaryCmd.Flags().IntVarP(&calYear, "year", "y", 0, "Year (default current)")
-	dateSummaryCmd.Flags().IntVarP(&calMonth, "month", "m", 0, "Month (1-12, optional; omit for full year)")
+	dateSummaryCmd.Flags().IntVarP(&summaryYear, "year", "y", 0, "Year (default current)")
+	dateSummaryCmd.Flags().IntVarP(&summaryMonth, "month", "m", 0, "Month (1-12, optional; omit for full year)")
+	dateSummaryCmd.Flags().BoolVarP(&dateForce, "force", "f", false, "Refresh cached months too; the open period and periods cached before closing are always fetched")
 	rootCmd.AddCommand(dateSummaryCmd)
 }
 
@@ -47,7 +52,7 @@ var dateSummaryCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg, err := config.InitializeConfig()
 		if err != nil {
-			fmt.Printf("Error loading config: %v\n", err)
+			fmt.Println(i18n.T("Error loading config: %v", err))
 			return
 		}
 
@@ -57,35 +62,41 @@ var dateSummaryCmd = &cobra.Command{
 		}
 		profile, profileExists := appConfig.Profiles[currentProfileName]
 		if !profileExists {
-			log.Fatalf("Profile '%s' not found", currentProfileName)
+			log.Fatal(i18n.T("Profile '%s' not found", currentProfileName))
 		}
 
 		n := time.Now()
-		if calYear == 0 {
-			calYear = n.Year()
+		if summaryYear == 0 {
+			summaryYear = n.Year()
 		}
 
 		// ── fetch timesheets ───────────────────────────────────────────────
-		var timesheets []mantis.TimesheetsResponse
-		if calMonth == 0 {
+		periods := []YearMonth{{Year: summaryYear, Month: time.Month(summaryMonth)}}
+		if summaryMonth == 0 {
+			periods = periods[:0]
 			for m := time.January; m <= time.December; m++ {
-				tss, err := mantisClient.Timesheet.GetTimesheets(mantisCtx, currentUserID, calYear, m)
-				if err != nil {
-					fmt.Printf("Error fetching month %d: %v\n", m, err)
-					continue
-				}
-				timesheets = append(timesheets, tss...)
+				periods = append(periods, YearMonth{Year: summaryYear, Month: m})
+			}
+		}
+
+		fetcher := newMonthFetcher(dateForce)
+		fetcher.RefreshOpen = true
+		byPeriod, err := fetcher.Timesheets(mantisCtx, periods)
+		if err != nil {
+			if mantisCtx.Err() != nil {
+				log.Fatal(err)
 			}
-		} else {
-			timesheets, err = mantisClient.Timesheet.GetTimesheets(
-				mantisCtx, currentUserID, calYear, time.Month(calMonth),
-			)
-			if err != nil {
-				fmt.Printf("Error fetching timesheets: %v\n", err)
+			fmt.Println(i18n.T("Error fetching timesheets: %v", err))
+			if len(byPeriod) == 0 {
 				return
 			}
 		}
 
+		var timesheets []mantis.TimesheetsResponse
+		for _, ym := range periods {
+			timesheets = append(timesheets, byPeriod[ym]...)
+		}
+
 		// ── apply optional timesheet filter ───────────────────────────────
 		if dateTSFilterFlag != "" {
 			query := resolveFilter(dateTSFilterFlag, cfg.SavedFilters)
@@ -117,23 +128,24 @@ var dateSummaryCmd = &cobra.Command{
 			}),
 		)
 
-		table.Header("DATE", "HOURS", "STATUS", "PROJECT", "USER", "WEEK", "MONTH", "WORKLOAD")
+		table.Header(i18n.T("DATE"), i18n.T("HOURS"), i18n.T("STATUS"), i18n.T("PROJECT"), i18n.T("USER"), i18n.T("WEEK"), i18n.T("MONTH"), i18n.T("WORKLOAD"))
 
 		for _, s := range summaries {
 			var statusColor *color.Color
 			switch s.Status {
 			case "MISSING":
-				statusColor = color.New(color.FgHiRed)
+				statusColor = utils.SlaBad
 			case "OVERTIME":
-				statusColor = color.New(color.FgHiYellow)
+				statusColor = utils.SlaWarn
 			default:
-				statusColor = color.New(color.FgHiGreen)
+				statusColor = utils.SlaGood
 			}
 
 			table.Append([]any{
 				s.Date,
-				fmt.Sprintf("%.2f", s.Hours),
-				statusColor.Sprint(s.Status),
+				i18n.Float(s.Hours, 2),
+				// Filters match the untranslated status.
+				statusColor.Sprint(i18n.T(s.Status)),
 				s.Project,
 				s.User,
 				fmt.Sprintf("%d", s.Week),
@@ -144,14 +156,14 @@ var dateSummaryCmd = &cobra.Command{
 
 		table.Render()
 
-		fmt.Println("\nWEEKLY TOTALS:")
+		fmt.Println("\n" + i18n.T("WEEKLY TOTALS:"))
 		weeks := make([]string, 0, len(weeklyTotals))
 		for w := range weeklyTotals {
 			weeks = append(weeks, w)
 		}
 		sort.Strings(weeks)
 		for _, w := range weeks {
-			fmt.Printf("  %s: %.2f hours\n", w, weeklyTotals[w])
+			fmt.Println(i18n.T("  %s: %s hours", w, i18n.Float(weeklyTotals[w], 2)))
 		}
 
 		printMonthlyTotals(monthlyTotals)
@@ -162,7 +174,7 @@ var dateSummaryCmd = &cobra.Command{
 			days++
 		}
 		if days > 0 {
-			fmt.Printf("\nAVERAGE DAILY HOURS: %.2f (target %.2f)\n", total/days, minDailyHours)
+			fmt.Println("\n" + i18n.T("AVERAGE DAILY HOURS: %s (target %s)", i18n.Float(total/days, 2), i18n.Float(minDailyHours, 2)))
 		}
 	},
 }
@@ -184,8 +196,8 @@ func printMonthlyTotals(monthlyTotals map[string]float64) {
 
 	sort.Slice(items, func(i, j int) bool { return items[i].t.Before(items[j].t) })
 
-	fmt.Println("\nMONTHLY TOTALS:")
+	fmt.Println("\n" + i18n.T("MONTHLY TOTALS:"))
 	for _, it := range items {
-		fmt.Printf("  %s: %.2f hours\n", it.key, monthlyTotals[it.key])
+		fmt.Println(i18n.T("  %s: %s hours", it.key, i18n.Float(monthlyTotals[it.key], 2)))
 	}
 }
@@ -3,8 +3,8 @@ package cmd
 import (
 	"fmt"
 	"log"
-	"time"
 
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/IntraCLI/utils"
 	"github.com/Salvadego/mantis/mantis"
 	"github.com/spf13/cobra"
@@ -13,12 +13,16 @@ import (
 var (
 	timesheetID      int
 	deleteFilterFlag string
+	deleteFromFlag   string
+	deleteToFlag     string
 )
 
 func init() {
 	deleteTimesheetCmd.Flags().IntVarP(&timesheetID, "id", "i", 0, "ID of the timesheet to delete")
 	deleteTimesheetCmd.Flags().StringVar(&deleteFilterFlag, "filter", "",
 		"Batch-delete by filter: raw qlvm query or @savedName")
+	deleteTimesheetCmd.Flags().StringVar(&deleteFromFlag, "from", "", "Search timesheets from this date (YYYY-MM-DD or token, defaults to the filter bounds)")
+	deleteTimesheetCmd.Flags().StringVar(&deleteToFlag, "to", "", "Search timesheets up to this date (YYYY-MM-DD or token, defaults to the filter bounds)")
 
 	deleteTimesheetCmd.RegisterFlagCompletionFunc("id", timesheetIdCompletionFunc)
 	deleteTimesheetCmd.RegisterFlagCompletionFunc("filter", filterNameCompletionFunc)
@@ -32,7 +36,7 @@ var deleteTimesheetCmd = &cobra.Command{
 	Long:  `Deletes a timesheet entry by ID or batch-deletes by filter. Use 'list-timesheets' to find IDs.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if timesheetID == 0 && deleteFilterFlag == "" {
-			log.Fatal("Error: no timesheet ID or filter provided. Use --id (-i) or --filter.")
+			log.Fatal(i18n.T("Error: no timesheet ID or filter provided. Use --id (-i) or --filter."))
 		}
 
 		client := mantisClient
@@ -49,25 +53,30 @@ var deleteTimesheetCmd = &cobra.Command{
 		if deleteFilterFlag != "" {
 			query := resolveFilter(deleteFilterFlag, appConfig.SavedFilters)
 
-			all, err := client.Timesheet.GetTimesheets(ctx, currentUserID, time.Now().Year(), time.Now().Month())
+			r, err := planDateRange(query, deleteFromFlag, deleteToFlag)
 			if err != nil {
-				log.Fatalf("Failed to fetch timesheets: %v", err)
+				log.Fatal(err)
+			}
+
+			all, err := fetchTimesheetsInRange(ctx, r, true)
+			if err != nil {
+				log.Fatal(i18n.T("Failed to fetch timesheets: %v", err))
 			}
 
 			timesheets = utils.ApplyFilter(all, query, profile)
 		}
 
 		if timesheetID != 0 {
-			fmt.Printf("Attempting to delete timesheet: %d\n", timesheetID)
+			fmt.Println(i18n.T("Attempting to delete timesheet: %d", timesheetID))
 			if err := client.Timesheet.DeleteTimesheet(ctx, timesheetID); err != nil {
-				log.Fatalf("Error deleting timesheet %d: %v", timesheetID, err)
+				log.Fatal(i18n.T("Error deleting timesheet %d: %v", timesheetID, err))
 			}
 		}
 
 		for _, ts := range timesheets {
-			fmt.Printf("Attempting to delete timesheet: %d\n", ts.TimesheetID)
+			fmt.Println(i18n.T("Attempting to delete timesheet: %d", ts.TimesheetID))
 			if err := client.Timesheet.DeleteTimesheet(ctx, ts.TimesheetID); err != nil {
-				log.Fatalf("Error deleting timesheet %d: %v", ts.TimesheetID, err)
+				log.Fatal(i18n.T("Error deleting timesheet %d: %v", ts.TimesheetID, err))
 			}
 		}
 	},
@@ -13,6 +13,7 @@ import (
 
 	"github.com/Salvadego/IntraCLI/cache"
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/IntraCLI/types"
 	"github.com/Salvadego/IntraCLI/utils"
 	"github.com/Salvadego/mantis/mantis"
@@ -29,6 +30,8 @@ var (
 	editTimesheetType string
 	editUseEditor     bool
 	editFilterFlag    string
+	editFromFlag      string
+	editToFlag        string
 )
 
 func init() {
@@ -42,6 +45,8 @@ func init() {
 	editCmd.Flags().BoolVarP(&editUseEditor, "editor", "e", false, "Open editor for editing")
 	editCmd.Flags().StringVar(&editFilterFlag, "filter", "",
 		"Batch-edit by filter: raw qlvm query or @savedName")
+	editCmd.Flags().StringVar(&editFromFlag, "from", "", "Search timesheets from this date (YYYY-MM-DD or token, defaults to the filter bounds)")
+	editCmd.Flags().StringVar(&editToFlag, "to", "", "Search timesheets up to this date (YYYY-MM-DD or token, defaults to the filter bounds)")
 
 	editCmd.RegisterFlagCompletionFunc("type", typeCompletionFunc)
 	editCmd.RegisterFlagCompletionFunc("project-alias", projectAliasCompletionFunc)
@@ -70,26 +75,31 @@ var editCmd = &cobra.Command{
 		case editTimesheetID != 0:
 			ts, err := client.Timesheet.Get(ctx, editTimesheetID)
 			if err != nil {
-				log.Fatalf("Failed to fetch timesheet %d: %v", editTimesheetID, err)
+				log.Fatal(i18n.T("Failed to fetch timesheet %d: %v", editTimesheetID, err))
 			}
 			timesheets = append(timesheets, ts[0])
 
 		case editFilterFlag != "":
 			query := resolveFilter(editFilterFlag, appConfig.SavedFilters)
 
-			all, err := client.Timesheet.GetTimesheets(ctx, currentUserID, time.Now().Year(), time.Now().Month())
+			r, err := planDateRange(query, editFromFlag, editToFlag)
 			if err != nil {
-				log.Fatalf("Failed to fetch timesheets: %v", err)
+				log.Fatal(err)
+			}
+
+			all, err := fetchTimesheetsInRange(ctx, r, true)
+			if err != nil {
+				log.Fatal(i18n.T("Failed to fetch timesheets: %v", err))
 			}
 
 			timesheets = utils.ApplyFilter(all, query, profile)
 
 		default:
-			log.Fatal("Must provide either --id or --filter")
+			log.Fatal(i18n.T("Must provide either --id or --filter"))
 		}
 
 		if len(timesheets) == 0 {
-			fmt.Println("No timesheets matched the criteria.")
+			fmt.Println(i18n.T("No timesheets matched the criteria."))
 			return
 		}
 
@@ -103,7 +113,7 @@ var editCmd = &cobra.Command{
 			if editHours != "" {
 				h, err := parseDurationString(editHours)
 				if err != nil {
-					log.Fatalf("Invalid hours format: %v", err)
+					log.Fatal(i18n.T("Invalid hours format: %v", err))
 				}
 				hours = h
 			}
@@ -111,7 +121,7 @@ var editCmd = &cobra.Command{
 			date := ts.DateDoc[:10]
 			if editDate != "" {
 				if _, err := time.Parse("2006-01-02", editDate); err != nil {
-					log.Fatalf("Invalid date format: %v", err)
+					log.Fatal(i18n.T("Invalid date format: %v", err))
 				}
 				date = editDate
 			}
@@ -131,10 +141,10 @@ var editCmd = &cobra.Command{
 			if editProjectAlias != "" {
 				info, ok := profile.ProjectAliases[editProjectAlias]
 				if !ok {
-					log.Fatalf("Unknown project alias '%s'", editProjectAlias)
+					log.Fatal(i18n.T("Unknown project alias '%s'", editProjectAlias))
 				}
 				if info.NeedsTicket && tkn == "" {
-					log.Fatalf("Project '%s' requires a ticket", editProjectAlias)
+					log.Fatal(i18n.T("Project '%s' requires a ticket", editProjectAlias))
 				}
 				salesOrder = info.SalesOrder
 				salesOrderLine = info.SalesOrderLine
@@ -144,13 +154,13 @@ var editCmd = &cobra.Command{
 			if editTimesheetType != "" {
 				key, ok := types.TimesheetTypeLookup[editTimesheetType]
 				if !ok {
-					log.Fatalf("Unknown timesheet type '%s'", editTimesheetType)
+					log.Fatal(i18n.T("Unknown timesheet type '%s'", editTimesheetType))
 				}
 				tsType = key
 			}
 
 			if err := client.Timesheet.DeleteTimesheet(ctx, ts.TimesheetID); err != nil {
-				log.Printf("Failed to delete timesheet %d: %v", ts.TimesheetID, err)
+				log.Print(i18n.T("Failed to delete timesheet %d: %v", ts.TimesheetID, err))
 				continue
 			}
 
@@ -164,7 +174,7 @@ var editCmd = &cobra.Command{
 				SalesOrderLine: salesOrderLine,
 			}
 
-			fmt.Printf("Recreating timesheet: %+v\n", entry)
+			fmt.Println(i18n.T("Recreating timesheet: %+v", entry))
 			appoint(client, currentUserID, entry, ctx)
 		}
 	},
@@ -183,13 +193,7 @@ func processEditEditorFile(timesheets []mantis.TimesheetsResponse, profile confi
 	sb.WriteString("# Format: description, hours, date, project-alias, ticket, type\n\n")
 
 	for _, ts := range timesheets {
-		currentAlias := ""
-		for alias, info := range profile.ProjectAliases {
-			if info.SalesOrder == int(ts.SalesOrder) && info.SalesOrderLine == int(ts.SalesOrderLine) {
-				currentAlias = alias
-				break
-			}
-		}
+		currentAlias := projectAliasFor(profile, int(ts.SalesOrder), int(ts.SalesOrderLine))
 
 		fmt.Fprintf(&sb, "id: %d\n", ts.TimesheetID)
 		fmt.Fprintf(&sb, "description: %s\n", ts.Description)
@@ -202,7 +206,7 @@ func processEditEditorFile(timesheets []mantis.TimesheetsResponse, profile confi
 	}
 
 	if err := os.WriteFile(file, []byte(sb.String()), 0644); err != nil {
-		log.Fatalf("Failed to write temporary file: %v", err)
+		log.Fatal(i18n.T("Failed to write temporary file: %v", err))
 	}
 	initialContent, _ := os.ReadFile(file)
 
@@ -218,16 +222,16 @@ func processEditEditorFile(timesheets []mantis.TimesheetsResponse, profile confi
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
-		log.Fatalf("Error opening editor: %v", err)
+		log.Fatal(i18n.T("Error opening editor: %v", err))
 	}
 
 	contentBytes, err := os.ReadFile(file)
 	if err != nil {
-		log.Fatalf("Failed to read edited file: %v", err)
+		log.Fatal(i18n.T("Failed to read edited file: %v", err))
 	}
 
 	if string(initialContent) == string(contentBytes) {
-		fmt.Println("No changes detected. Aborting edit.")
+		fmt.Println(i18n.T("No changes detected. Aborting edit."))
 		return
 	}
 
@@ -255,13 +259,13 @@ func processEditEditorFile(timesheets []mantis.TimesheetsResponse, profile confi
 		alias := entryMap["project-alias"]
 		projectInfo, ok := profile.ProjectAliases[alias]
 		if !ok {
-			log.Printf("Skipping ID %d: unknown project-alias '%s'", oldID, alias)
+			log.Print(i18n.T("Skipping ID %d: unknown project-alias '%s'", oldID, alias))
 			continue
 		}
 
 		parsedHours, err := parseDurationString(entryMap["hours"])
 		if err != nil {
-			log.Printf("Skipping ID %d: invalid hours: %v", oldID, err)
+			log.Print(i18n.T("Skipping ID %d: invalid hours: %v", oldID, err))
 			continue
 		}
 
@@ -284,7 +288,7 @@ func processEditEditorFile(timesheets []mantis.TimesheetsResponse, profile confi
 			SalesOrderLine: projectInfo.SalesOrderLine,
 		}
 
-		fmt.Printf("Updating timesheet %d...\n", oldID)
+		fmt.Println(i18n.T("Updating timesheet %d...", oldID))
 		fmt.Println(entry)
 		// if err := client.Timesheet.DeleteTimesheet(ctx, oldID); err != nil {
 		// 	log.Printf("Failed to delete old timesheet %d: %v", oldID, err)
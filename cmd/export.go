@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/spf13/cobra"
+)
+
+var (
+	icsFrom     string
+	icsTo       string
+	icsFilter   string
+	icsOutput   string
+	icsDayStart string
+	icsForce    bool
+	icsServe    bool
+	icsPort     int
+	icsRefresh  time.Duration
+)
+
+func init() {
+	exportICSCmd.Flags().StringVar(&icsFrom, "from", "this-month", "Start date (YYYY-MM-DD or token)")
+	exportICSCmd.Flags().StringVar(&icsTo, "to", "today", "End date (YYYY-MM-DD or token)")
+	exportICSCmd.Flags().StringVar(&icsFilter, "filter", "", "Filter: raw qlvm query or @savedName")
+	exportICSCmd.Flags().StringVarP(&icsOutput, "output", "o", "", "Write to this file instead of stdout")
+	exportICSCmd.Flags().StringVar(&icsDayStart, "day-start", "09:00", "Time the first entry of each day starts at")
+	exportICSCmd.Flags().BoolVarP(&icsForce, "force", "f", false, "Force refresh instead of reading cached months")
+	exportICSCmd.Flags().BoolVar(&icsServe, "serve", false, "Serve the feed over HTTP on localhost")
+	exportICSCmd.Flags().IntVar(&icsPort, "port", 8765, "Port for --serve")
+	exportICSCmd.Flags().DurationVar(&icsRefresh, "refresh", 15*time.Minute, "With --serve, how long a fetched feed is reused")
+
+	exportICSCmd.RegisterFlagCompletionFunc("filter", filterNameCompletionFunc)
+
+	exportCmd.AddCommand(exportICSCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export timesheets to other formats",
+}
+
+var exportICSCmd = &cobra.Command{
+	Use:   "ics",
+	Short: "Export timesheets and non-business days as an iCalendar feed",
+	Long: `Writes one event per timesheet entry between --from and --to, with the
+description, ticket, project and type. Mantis does not record start times, so
+the entries of a day are stacked one after the other from --day-start.
+Non-business days from the Mantis calendar are all-day events.
+
+With --serve the feed is served on http://127.0.0.1:PORT/intracli.ics for
+calendar apps to subscribe to. The dates are re-evaluated on every request,
+so tokens like this-month keep moving, and Mantis is asked again once the
+last feed is older than --refresh.
+
+Examples:
+  intracli export ics > timesheets.ics
+  intracli export ics --from 2026-01-01 --to today -o 2026.ics
+  intracli export ics --from last-month --serve --port 8765`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dayStart, err := time.Parse("15:04", icsDayStart)
+		if err != nil {
+			log.Fatal(i18n.T("Invalid --day-start %q: expected HH:MM", icsDayStart))
+		}
+		profile, err := getCurrentProfile(appConfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+		query := resolveFilter(icsFilter, appConfig.SavedFilters)
+		offset := time.Duration(dayStart.Hour())*time.Hour + time.Duration(dayStart.Minute())*time.Minute
+
+		build := func(force bool) ([]byte, error) {
+			return buildICSFeed(profile, query, offset, force)
+		}
+
+		if icsServe {
+			if err := serveICS(build); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		feed, err := build(icsForce)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if icsOutput == "" {
+			os.Stdout.Write(feed)
+			return
+		}
+		if err := os.WriteFile(icsOutput, feed, 0644); err != nil {
+			log.Fatal(i18n.T("Failed to write %s: %v", icsOutput, err))
+		}
+		fmt.Println(i18n.T("Wrote %s", icsOutput))
+	},
+}
+
+// buildICSFeed fetches the range of --from/--to and renders it as ICS.
+func buildICSFeed(profile config.Profile, query string, dayStart time.Duration, force bool) ([]byte, error) {
+	from, err := parseRangeDate(icsFrom)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseRangeDate(icsTo)
+	if err != nil {
+		return nil, err
+	}
+	if from.After(to) {
+		return nil, i18n.Errorf("invalid range: %s is after %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	r := dateRange{From: from, To: to}
+
+	timesheets, err := fetchTimesheetsInRange(mantisCtx, r, force)
+	if err != nil {
+		return nil, i18n.Errorf("error getting timesheets: %w", err)
+	}
+	timesheets = utils.ApplyFilter(timesheets, query, profile)
+
+	// Non-business days are kept per calendar month, as in cal.
+	var months []YearMonth
+	for m := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.Local); !m.After(to); m = m.AddDate(0, 1, 0) {
+		months = append(months, YearMonth{Year: m.Year(), Month: m.Month()})
+	}
+	nbByMonth, err := newMonthFetcher(force).NonBusinessDays(mantisCtx, months)
+	if err != nil {
+		if mantisCtx.Err() != nil {
+			return nil, err
+		}
+		log.Print(i18n.T("Warning: failed to get non-business days: %v", err))
+	}
+
+	var events []utils.ICSEvent
+	for _, ym := range months {
+		for _, d := range nbByMonth[ym] {
+			day := time.Date(d.Date.Year(), d.Date.Month(), d.Date.Day(), 0, 0, 0, 0, time.Local)
+			if !r.contains(day) {
+				continue
+			}
+			events = append(events, utils.ICSEvent{
+				UID:        fmt.Sprintf("nonbusiness-%s@intracli", day.Format("20060102")),
+				Summary:    d.Name,
+				Categories: []string{"Non-business day"},
+				Start:      day,
+				End:        day.AddDate(0, 0, 1),
+				AllDay:     true,
+			})
+		}
+	}
+	events = append(events, timesheetEvents(profile, timesheets, dayStart)...)
+
+	var buf bytes.Buffer
+	if err := utils.WriteICS(&buf, "IntraCLI timesheets", events, time.Now()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// timesheetEvents turns entries into events, stacking the entries of each
+// day from dayStart in the order they are listed.
+func timesheetEvents(profile config.Profile, timesheets []mantis.TimesheetsResponse, dayStart time.Duration) []utils.ICSEvent {
+	next := map[string]time.Time{}
+	events := make([]utils.ICSEvent, 0, len(timesheets))
+	for _, ts := range timesheets {
+		key := ts.DateDoc[:10]
+		start, ok := next[key]
+		if !ok {
+			day, err := time.ParseInLocation("2006-01-02", key, time.Local)
+			if err != nil {
+				continue
+			}
+			start = day.Add(dayStart)
+		}
+		end := start.Add(time.Duration(ts.Quantity * float64(time.Hour)))
+		next[key] = end
+
+		project := projectAliasFor(profile, int(ts.SalesOrder), int(ts.SalesOrderLine))
+		if project == "" {
+			project = ts.ProjectName
+		}
+		summary := strings.Join(strings.Fields(ts.Description), " ")
+		if ts.TicketNo != "" {
+			summary = ts.TicketNo + " · " + summary
+		}
+
+		desc := []string{ts.Description, ""}
+		desc = append(desc, "Hours: "+strconv.FormatFloat(ts.Quantity, 'f', 2, 64))
+		if ts.TicketNo != "" {
+			desc = append(desc, "Ticket: "+ts.TicketNo)
+		}
+		if project != "" {
+			desc = append(desc, "Project: "+project)
+		}
+		if ts.TimesheetType != "" {
+			desc = append(desc, "Type: "+timesheetTypeName(ts.TimesheetType))
+		}
+		desc = append(desc, fmt.Sprintf("Timesheet ID: %d", ts.TimesheetID))
+
+		var categories []string
+		if project != "" {
+			categories = append(categories, project)
+		}
+		events = append(events, utils.ICSEvent{
+			UID:         fmt.Sprintf("timesheet-%d@intracli", ts.TimesheetID),
+			Summary:     summary,
+			Description: strings.Join(desc, "\n"),
+			Categories:  categories,
+			Start:       start,
+			End:         end,
+		})
+	}
+	return events
+}
+
+// serveICS serves the feed on localhost until Ctrl-C, rebuilding it when
+// the last one is older than --refresh.
+func serveICS(build func(force bool) ([]byte, error)) error {
+	var (
+		mu      sync.Mutex
+		feed    []byte
+		builtAt time.Time
+	)
+	port := strconv.Itoa(icsPort)
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		// Only answer for the loopback names, so a page rebinding its own
+		// domain to 127.0.0.1 cannot read the feed.
+		if req.Host != "127.0.0.1:"+port && req.Host != "localhost:"+port {
+			http.Error(w, "forbidden host", http.StatusForbidden)
+			return
+		}
+		if req.URL.Path != "/intracli.ics" {
+			http.NotFound(w, req)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if feed == nil || time.Since(builtAt) >= icsRefresh {
+			// The first build may use the cache; later ones refresh.
+			f, err := build(icsForce || feed != nil)
+			if err != nil {
+				log.Print(i18n.T("Error building feed: %v", err))
+				if feed == nil {
+					http.Error(w, err.Error(), http.StatusBadGateway)
+					return
+				}
+			} else {
+				feed, builtAt = f, time.Now()
+			}
+		}
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Last-Modified", builtAt.UTC().Format(http.TimeFormat))
+		w.Write(feed)
+	}
+
+	addr := net.JoinHostPort("127.0.0.1", port)
+	srv := &http.Server{Addr: addr, Handler: http.HandlerFunc(handler), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-mantisCtx.Done()
+		srv.Close()
+	}()
+
+	fmt.Println(i18n.T("Serving the feed on http://%s/intracli.ics", addr))
+	utils.MutedStyle.Println(i18n.T("Press Ctrl+C to stop."))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/mattn/go-isatty"
+)
+
+var fetchConcurrency int
+
+// MonthFetcher loads per-period data (timesheets, non-business days) for
+// many periods at once. Cache hits are served first; the remaining periods
+// are fetched concurrently, at most Concurrency at a time, and written back
+// to the cache. Cancelling the context (Ctrl-C) stops pending fetches.
+type MonthFetcher struct {
+	UserID      int
+	Concurrency int
+	// Force skips cache reads; fetched data is still cached.
+	Force bool
+	// RefreshOpen skips cache reads for the period holding today, which is
+	// still being logged, and for periods cached before they closed.
+	RefreshOpen bool
+	// Progress receives a one-line progress indicator. nil disables it.
+	Progress io.Writer
+}
+
+// newMonthFetcher builds a fetcher for the current user, honoring the
+// --concurrency flag, then the config, then maxConcurrentFetches.
+func newMonthFetcher(force bool) *MonthFetcher {
+	concurrency := fetchConcurrency
+	if concurrency <= 0 && appConfig != nil {
+		concurrency = appConfig.FetchConcurrency
+	}
+	if concurrency <= 0 {
+		concurrency = maxConcurrentFetches
+	}
+
+	var progress io.Writer
+	if isatty.IsTerminal(os.Stderr.Fd()) {
+		progress = os.Stderr
+	}
+
+	return &MonthFetcher{
+		UserID:      currentUserID,
+		Concurrency: concurrency,
+		Force:       force,
+		Progress:    progress,
+	}
+}
+
+// Timesheets returns the timesheets of every requested period.
+func (f *MonthFetcher) Timesheets(
+	ctx context.Context,
+	periods []YearMonth,
+) (map[YearMonth][]mantis.TimesheetsResponse, error) {
+	return fetchPeriods(ctx, f, "timesheets", periods,
+		func(ym YearMonth) string { return timesheetCacheKey(f.UserID, ym.Year, ym.Month) },
+		func(ctx context.Context, ym YearMonth) ([]mantis.TimesheetsResponse, error) {
+			return mantisClient.Timesheet.GetTimesheets(ctx, f.UserID, ym.Year, ym.Month)
+		},
+	)
+}
+
+// NonBusinessDays returns the non-business days of every requested period.
+func (f *MonthFetcher) NonBusinessDays(
+	ctx context.Context,
+	periods []YearMonth,
+) (map[YearMonth][]mantis.NonBusinessDay, error) {
+	return fetchPeriods(ctx, f, "non-business days", periods,
+		func(ym YearMonth) string { return nonBusinessCacheKey(ym.Year, ym.Month) },
+		func(ctx context.Context, ym YearMonth) ([]mantis.NonBusinessDay, error) {
+			return mantisClient.Calendar.GetNonBusinessDays(ctx, ym.Year, ym.Month)
+		},
+	)
+}
+
+// fetchPeriods is the shared worker pool behind the MonthFetcher methods.
+// Periods that fail are left out of the result and reported in the error.
+func fetchPeriods[T any](
+	ctx context.Context,
+	f *MonthFetcher,
+	label string,
+	periods []YearMonth,
+	cacheKey func(YearMonth) string,
+	get func(context.Context, YearMonth) ([]T, error),
+) (map[YearMonth][]T, error) {
+	out := make(map[YearMonth][]T, len(periods))
+
+	var pending []YearMonth
+	for _, ym := range periods {
+		if !f.Force && !(f.RefreshOpen && cachedWhileOpen(cacheKey(ym), ym)) {
+			cached, _ := cache.ReadFromCache[T](cacheKey(ym))
+			if len(cached) > 0 {
+				out[ym] = cached
+				continue
+			}
+		}
+		pending = append(pending, ym)
+	}
+
+	if len(pending) == 0 {
+		return out, nil
+	}
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		done int
+		wg   sync.WaitGroup
+	)
+
+	f.report(label, 0, len(pending))
+
+	jobs := make(chan YearMonth)
+	for range max(1, min(f.Concurrency, len(pending))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ym := range jobs {
+				data, err := get(ctx, ym)
+				if err == nil {
+					filename := cacheKey(ym)
+					if werr := cache.WriteToCache(filename, data); werr != nil {
+						log.Print(i18n.T("Warning: failed to write cache (%s): %v", filename, werr))
+					}
+				}
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s %04d-%02d: %w", i18n.T(label), ym.Year, ym.Month, err))
+				} else {
+					out[ym] = data
+				}
+				done++
+				f.report(label, done, len(pending))
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for _, ym := range pending {
+		select {
+		case jobs <- ym:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	f.clearProgress()
+
+	if err := ctx.Err(); err != nil {
+		return out, err
+	}
+	return out, errors.Join(errs...)
+}
+
+// cachedWhileOpen reports whether the cache file of period ym was written
+// before the period closed, so it may miss entries logged afterwards.
+func cachedWhileOpen(filename string, ym YearMonth) bool {
+	closed := time.Date(ym.Year, ym.Month, periodCloseDay+1, 0, 0, 0, 0, time.Local)
+	written, err := cache.GetCacheModTime(filename)
+	return err != nil || written.Before(closed)
+}
+
+func (f *MonthFetcher) report(label string, done, total int) {
+	if f.Progress == nil {
+		return
+	}
+	fmt.Fprint(f.Progress, "\r"+i18n.T("Fetching %s %d/%d…", i18n.T(label), done, total))
+}
+
+func (f *MonthFetcher) clearProgress() {
+	if f.Progress == nil {
+		return
+	}
+	fmt.Fprint(f.Progress, "\r\033[K")
+}
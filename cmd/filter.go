@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -64,15 +65,15 @@ Examples:
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg, err := config.InitializeConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			log.Fatal(i18n.T("Error loading config: %v", err))
 		}
 
 		if filterList {
 			if len(cfg.SavedFilters) == 0 {
-				fmt.Println("No saved filters.")
+				fmt.Println(i18n.T("No saved filters."))
 				return
 			}
-			fmt.Println("Saved timesheet filters:")
+			fmt.Println(i18n.T("Saved timesheet filters:"))
 			for name, q := range cfg.SavedFilters {
 				fmt.Printf("  %-20s  %s\n", name, q)
 			}
@@ -81,18 +82,18 @@ Examples:
 
 		if filterDeleteName != "" {
 			if _, ok := cfg.SavedFilters[filterDeleteName]; !ok {
-				log.Fatalf("Filter '%s' not found.", filterDeleteName)
+				log.Fatal(i18n.T("Filter '%s' not found.", filterDeleteName))
 			}
 			delete(cfg.SavedFilters, filterDeleteName)
 			if err := config.SaveConfig(cfg); err != nil {
-				log.Fatalf("Failed to save config: %v", err)
+				log.Fatal(i18n.T("Failed to save config: %v", err))
 			}
-			fmt.Printf("Filter '%s' deleted.\n", filterDeleteName)
+			fmt.Println(i18n.T("Filter '%s' deleted.", filterDeleteName))
 			return
 		}
 
 		if filterSaveName == "" {
-			fmt.Println("No action taken. Use --save <name> [query], --list, or --delete <name>.")
+			fmt.Println(i18n.T("No action taken. Use --save <name> [query], --list, or --delete <name>."))
 			return
 		}
 
@@ -106,8 +107,8 @@ Examples:
 		}
 		cfg.SavedFilters[filterSaveName] = query
 		if err := config.SaveConfig(cfg); err != nil {
-			log.Fatalf("Failed to save config: %v", err)
+			log.Fatal(i18n.T("Failed to save config: %v", err))
 		}
-		fmt.Printf("Filter '%s' saved: %q\n", filterSaveName, query)
+		fmt.Println(i18n.T("Filter '%s' saved: %q", filterSaveName, query))
 	},
 }
@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -59,15 +60,15 @@ Examples:
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg, err := config.InitializeConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			log.Fatal(i18n.T("Error loading config: %v", err))
 		}
 
 		if filterDaysList {
 			if len(cfg.SavedDayFilters) == 0 {
-				fmt.Println("No saved day filters.")
+				fmt.Println(i18n.T("No saved day filters."))
 				return
 			}
-			fmt.Println("Saved day filters:")
+			fmt.Println(i18n.T("Saved day filters:"))
 			for name, q := range cfg.SavedDayFilters {
 				fmt.Printf("  %-20s  %s\n", name, q)
 			}
@@ -76,18 +77,18 @@ Examples:
 
 		if filterDaysDelete != "" {
 			if _, ok := cfg.SavedDayFilters[filterDaysDelete]; !ok {
-				log.Fatalf("Filter '%s' not found.", filterDaysDelete)
+				log.Fatal(i18n.T("Filter '%s' not found.", filterDaysDelete))
 			}
 			delete(cfg.SavedDayFilters, filterDaysDelete)
 			if err := config.SaveConfig(cfg); err != nil {
-				log.Fatalf("Failed to save config: %v", err)
+				log.Fatal(i18n.T("Failed to save config: %v", err))
 			}
-			fmt.Printf("Filter '%s' deleted.\n", filterDaysDelete)
+			fmt.Println(i18n.T("Filter '%s' deleted.", filterDaysDelete))
 			return
 		}
 
 		if saveDaysFilter == "" {
-			fmt.Println("No action taken. Use --save <n> [query], --list, or --delete <n>.")
+			fmt.Println(i18n.T("No action taken. Use --save <n> [query], --list, or --delete <n>."))
 			return
 		}
 
@@ -101,8 +102,8 @@ Examples:
 		}
 		cfg.SavedDayFilters[saveDaysFilter] = query
 		if err := config.SaveConfig(cfg); err != nil {
-			log.Fatalf("Failed to save config: %v", err)
+			log.Fatal(i18n.T("Failed to save config: %v", err))
 		}
-		fmt.Printf("Filter '%s' saved: %q\n", saveDaysFilter, query)
+		fmt.Println(i18n.T("Filter '%s' saved: %q", saveDaysFilter, query))
 	},
 }
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/spf13/cobra"
+)
+
+var (
+	saveTicketsFilter   string
+	filterTicketsDelete string
+	filterTicketsList   bool
+)
+
+func init() {
+	filterTicketsCmd.Flags().BoolVar(&filterTicketsList, "list", false, "List saved ticket filters")
+	filterTicketsCmd.Flags().StringVar(&saveTicketsFilter, "save", "", "Save the query with the given name")
+	filterTicketsCmd.Flags().StringVar(&filterTicketsDelete, "delete", "", "Delete the named filter")
+
+	filterTicketsCmd.RegisterFlagCompletionFunc("delete", filterTicketsNameCompletionFunc)
+
+	rootCmd.AddCommand(filterTicketsCmd)
+}
+
+// filterTicketsCmd manages saved qlvm query strings for ticket filtering.
+//
+// Saving a filter:
+//
+//	intracli filter-tickets --save urgent "sla < 60 AND priority <= 2"
+//
+// Using a saved filter:
+//
+//	intracli tickets --filter @urgent
+//
+// See utils.TicketRecord for the fields available.
+var filterTicketsCmd = &cobra.Command{
+	Use:   "filter-tickets [query]",
+	Short: "Manage saved ticket filters (qlvm query strings)",
+	Long: `Save, list, or delete named qlvm query strings used to filter tickets
+with 'tickets --filter @name'.
+
+The optional positional argument is the raw qlvm query string to save.
+
+Examples:
+  intracli filter-tickets --save urgent "sla < 60 AND priority <= 2"
+  intracli filter-tickets --save sefaz  ".sefaz"
+  intracli filter-tickets --save recent "created >= '7d-ago'"
+  intracli filter-tickets --list
+  intracli filter-tickets --delete urgent`,
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.InitializeConfig()
+		if err != nil {
+			log.Fatal(i18n.T("Error loading config: %v", err))
+		}
+
+		if filterTicketsList {
+			if len(cfg.SavedTicketFilters) == 0 {
+				fmt.Println(i18n.T("No saved ticket filters."))
+				return
+			}
+			fmt.Println(i18n.T("Saved ticket filters:"))
+			for name, q := range cfg.SavedTicketFilters {
+				fmt.Printf("  %-20s  %s\n", name, q)
+			}
+			return
+		}
+
+		if filterTicketsDelete != "" {
+			if _, ok := cfg.SavedTicketFilters[filterTicketsDelete]; !ok {
+				log.Fatal(i18n.T("Filter '%s' not found.", filterTicketsDelete))
+			}
+			delete(cfg.SavedTicketFilters, filterTicketsDelete)
+			if err := config.SaveConfig(cfg); err != nil {
+				log.Fatal(i18n.T("Failed to save config: %v", err))
+			}
+			fmt.Println(i18n.T("Filter '%s' deleted.", filterTicketsDelete))
+			return
+		}
+
+		if saveTicketsFilter == "" {
+			fmt.Println(i18n.T("No action taken. Use --save <n> [query], --list, or --delete <n>."))
+			return
+		}
+
+		query := ""
+		if len(args) > 0 {
+			query = args[0]
+		}
+
+		if cfg.SavedTicketFilters == nil {
+			cfg.SavedTicketFilters = make(map[string]string)
+		}
+		cfg.SavedTicketFilters[saveTicketsFilter] = query
+		if err := config.SaveConfig(cfg); err != nil {
+			log.Fatal(i18n.T("Failed to save config: %v", err))
+		}
+		fmt.Println(i18n.T("Filter '%s' saved: %q", saveTicketsFilter, query))
+	},
+}
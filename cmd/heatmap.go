@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+)
+
+// heatmapWeek is a column of the heatmap; days outside the year are nil.
+type heatmapWeek [7]*DayInfo
+
+type heatmapStats struct {
+	Total   float64
+	Logged  int
+	Missing int
+	// Partial counts business days logged below the journey.
+	Partial int
+	// Streak is the longest run of business days with a full journey;
+	// weekends and holidays neither extend nor break it.
+	Streak               int
+	StreakFrom, StreakTo time.Time
+}
+
+// runHeatmap loads a whole year and renders it as a heatmap, or as SVG when
+// --svg is given.
+func runHeatmap(profile config.Profile, r Renderer, filterQuery string) error {
+	year := calCfg.Year
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	last := time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local)
+
+	timesheets, err := fetchTimesheetsInRange(mantisCtx, dateRange{From: first, To: last}, calCfg.Force)
+	if err != nil {
+		return i18n.Errorf("error getting timesheets: %w", err)
+	}
+	timesheets = utils.ApplyFilter(timesheets, filterQuery, profile)
+
+	var months []YearMonth
+	for m := time.January; m <= time.December; m++ {
+		months = append(months, YearMonth{Year: year, Month: m})
+	}
+	nbByMonth, err := newMonthFetcher(calCfg.Force).NonBusinessDays(mantisCtx, months)
+	if err != nil {
+		if mantisCtx.Err() != nil {
+			return err
+		}
+		log.Print(i18n.T("Warning: failed to get non-business days: %v", err))
+	}
+
+	hoursByDate := map[string]float64{}
+	dateAppointments := map[string][]mantis.TimesheetsResponse{}
+	for _, ts := range timesheets {
+		key := ts.DateDoc[:10]
+		hoursByDate[key] += ts.Quantity
+		dateAppointments[key] = append(dateAppointments[key], ts)
+	}
+
+	var days []DayInfo
+	for _, ym := range months {
+		nbMap := map[int]mantis.NonBusinessDay{}
+		for _, d := range nbByMonth[ym] {
+			nbMap[d.Date.Day()] = d
+		}
+		days = append(days, buildDays(year, ym.Month, hoursByDate, dateAppointments, nbMap, r.Now)...)
+	}
+
+	weeks := heatmapWeeks(days, r.Monday)
+	journey := func(day time.Time) float64 { return utils.JourneyFor(profile, day) }
+	stats := computeHeatmapStats(days, journey, r.Now)
+
+	if calCfg.SVG == "" {
+		r.renderHeatmap(year, weeks, journey, stats)
+		return nil
+	}
+	if calCfg.SVG == "-" {
+		return writeHeatmapSVG(os.Stdout, r, year, weeks, journey, stats)
+	}
+	f, err := os.Create(calCfg.SVG)
+	if err != nil {
+		return err
+	}
+	if err := writeHeatmapSVG(f, r, year, weeks, journey, stats); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Println(i18n.T("Wrote %s", calCfg.SVG))
+	return nil
+}
+
+// heatmapWeeks lays the days of a year out in week columns.
+func heatmapWeeks(days []DayInfo, monday bool) []heatmapWeek {
+	var weeks []heatmapWeek
+	var week heatmapWeek
+	for i := range days {
+		row := int(days[i].Date.Weekday())
+		if monday {
+			row = (row + 6) % 7
+		}
+		if row == 0 && i > 0 {
+			weeks = append(weeks, week)
+			week = heatmapWeek{}
+		}
+		week[row] = &days[i]
+	}
+	return append(weeks, week)
+}
+
+// computeHeatmapStats counts the year's logged, missing and partial days
+// against the journey expected on each day.
+func computeHeatmapStats(days []DayInfo, journey func(time.Time) float64, now time.Time) heatmapStats {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	var s heatmapStats
+	run := 0
+	var runFrom time.Time
+	for _, d := range days {
+		if d.Date.After(today) {
+			break
+		}
+		s.Total += d.Hours
+		if d.Hours > 0 {
+			s.Logged++
+		}
+		if d.IsWeekend || d.IsHoliday {
+			continue
+		}
+
+		full := d.Hours >= journey(d.Date)-0.001
+		switch {
+		case full:
+			if run == 0 {
+				runFrom = d.Date
+			}
+			run++
+			if run > s.Streak {
+				s.Streak, s.StreakFrom, s.StreakTo = run, runFrom, d.Date
+			}
+		case d.Date.Before(today):
+			run = 0
+			if d.Hours > 0 {
+				s.Partial++
+			} else {
+				s.Missing++
+			}
+		}
+	}
+	return s
+}
+
+// heatmapGlyph is the cell of a day: a filled square colored by hours,
+// a diamond for holidays, a hollow square for missed business days.
+func (r Renderer) heatmapGlyph(d *DayInfo, journey func(time.Time) float64) string {
+	if d == nil {
+		return " "
+	}
+	journeyHours := journey(d.Date)
+	glyph, color := "■", ""
+	switch {
+	case d.Hours > 0:
+		color = r.colorForHours(journeyHours, d.Hours)
+	case d.IsHoliday:
+		glyph, color = "◆", utils.SGR(utils.ActiveTheme.Holiday)
+	case d.Date.After(r.Now):
+		glyph = "·"
+	case d.IsWeekend:
+		glyph, color = "·", utils.SGR(utils.ActiveTheme.Weekend)
+	default:
+		glyph, color = "□", r.colorForHours(journeyHours, 0)
+	}
+	if r.NoColor {
+		color = ""
+	}
+	if d.IsToday && !r.NoColor {
+		color = INVERT + color
+	}
+	if color == "" {
+		return glyph
+	}
+	return color + glyph + RESET
+}
+
+func (r Renderer) renderHeatmap(year int, weeks []heatmapWeek, journey func(time.Time) float64, s heatmapStats) {
+	fmt.Printf("%s%d%s\n", BOLD, year, RESET)
+
+	// Month labels over the week their first day falls in.
+	var labels strings.Builder
+	labels.WriteString("   ")
+	col := 0
+	for i, w := range weeks {
+		for _, d := range w {
+			if d != nil && d.Date.Day() == 1 && col <= i*2 {
+				labels.WriteString(strings.Repeat(" ", i*2-col))
+				label := i18n.MonthShort(d.Date.Month())
+				labels.WriteString(label)
+				col = i*2 + len(label)
+			}
+		}
+	}
+	fmt.Println(labels.String())
+
+	for row, label := range r.weekdayLabels() {
+		var line strings.Builder
+		fmt.Fprintf(&line, "%-3s", label)
+		for _, w := range weeks {
+			line.WriteString(r.heatmapGlyph(w[row], journey))
+			line.WriteString(" ")
+		}
+		fmt.Println(strings.TrimRight(line.String(), " "))
+	}
+
+	fmt.Println()
+	// The legend scale is today's journey.
+	journeyHours := journeyHoursOrDefault(journey(r.Now))
+	var legend strings.Builder
+	legend.WriteString("   0h ")
+	for _, f := range []float64{0.01, 0.25, 0.5, 0.75, 1} {
+		legend.WriteString(r.colorForHours(journeyHours, f*journeyHours) + "■" + RESET + " ")
+	}
+	fmt.Fprintf(&legend, "%.0fh   ", journeyHours)
+	legend.WriteString(r.heatmapGlyph(&DayInfo{IsHoliday: true}, journey) + " " + i18n.T("holiday") + "  ")
+	legend.WriteString(r.heatmapGlyph(&DayInfo{}, journey) + " " + i18n.T("missing"))
+	fmt.Println(legend.String())
+
+	fmt.Println()
+	fmt.Println(i18n.T("Total logged:   %s over %d day(s)", i18n.Hours(s.Total), s.Logged))
+	fmt.Println(i18n.T("Days missing:   %d (and %d below journey)", s.Missing, s.Partial))
+	if s.Streak > 0 {
+		fmt.Println(i18n.T("Longest streak: %d business day(s), %s → %s",
+			s.Streak, s.StreakFrom.Format("2006-01-02"), s.StreakTo.Format("2006-01-02")))
+	} else {
+		fmt.Println(i18n.T("Longest streak: 0 business days"))
+	}
+}
+
+func journeyHoursOrDefault(journeyHours float64) float64 {
+	if journeyHours <= 0 {
+		return 8
+	}
+	return journeyHours
+}
+
+const (
+	svgCell   = 11
+	svgStep   = 13
+	svgLeft   = 32
+	svgTop    = 24
+	svgFont   = `font-family="-apple-system,Segoe UI,Helvetica,Arial,sans-serif" font-size="10" fill="#57606a"`
+	svgEmpty  = "#ebedf0"
+	svgCyan   = "#7daea3"
+	svgBorder = "rgba(27,31,36,0.06)"
+)
+
+func rgbHex(c RGB) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// svgFill mirrors heatmapGlyph for the SVG cells.
+func svgFill(d *DayInfo, journey func(time.Time) float64, now time.Time) string {
+	journeyHours := journey(d.Date)
+	switch {
+	case d.Hours > 0:
+		return rgbHex(hoursRGB(journeyHours, d.Hours))
+	case d.IsHoliday:
+		return svgCyan
+	case d.IsWeekend || d.Date.After(now):
+		return svgEmpty
+	}
+	return rgbHex(hoursRGB(journeyHours, 0))
+}
+
+// writeHeatmapSVG draws the heatmap, its legend and the stats as SVG.
+func writeHeatmapSVG(w io.Writer, r Renderer, year int, weeks []heatmapWeek, journey func(time.Time) float64, s heatmapStats) error {
+	width := svgLeft + len(weeks)*svgStep + 8
+	gridBottom := svgTop + 7*svgStep
+	height := gridBottom + 70
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+	fmt.Fprintf(&b, `<text x="%d" y="12" %s font-weight="bold">%d</text>`+"\n", svgLeft, svgFont, year)
+
+	for i, wk := range weeks {
+		for _, d := range wk {
+			if d != nil && d.Date.Day() == 1 {
+				fmt.Fprintf(&b, `<text x="%d" y="%d" %s>%s</text>`+"\n",
+					svgLeft+i*svgStep, svgTop-4, svgFont, i18n.MonthShort(d.Date.Month()))
+			}
+		}
+	}
+	for row, label := range r.weekdayLabels() {
+		if row%2 == 1 {
+			fmt.Fprintf(&b, `<text x="0" y="%d" %s>%s</text>`+"\n", svgTop+row*svgStep+9, svgFont, label)
+		}
+	}
+
+	for i, wk := range weeks {
+		for row, d := range wk {
+			if d == nil {
+				continue
+			}
+			title := fmt.Sprintf("%s: %s", d.Date.Format("2006-01-02"), i18n.Hours(d.Hours))
+			if d.IsHoliday {
+				title += " (" + i18n.T("holiday") + ")"
+			}
+			stroke := svgBorder
+			if d.IsToday {
+				stroke = "#24292f"
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s" stroke="%s"><title>%s</title></rect>`+"\n",
+				svgLeft+i*svgStep, svgTop+row*svgStep, svgCell, svgCell,
+				svgFill(d, journey, r.Now), stroke, html.EscapeString(title))
+		}
+	}
+
+	// Legend, right-aligned under the grid. Labels are about 6px a rune at
+	// font-size 10. The scale is today's journey.
+	scale := journeyHoursOrDefault(journey(r.Now))
+	holiday := i18n.T("holiday")
+	hx := width - 8 - 6*utf8.RuneCountInString(holiday)
+	lx := hx - 5*svgStep - 40
+	ly := gridBottom + 8
+	fmt.Fprintf(&b, `<text x="%d" y="%d" %s>0h</text>`+"\n", lx-18, ly+9, svgFont)
+	for i, f := range []float64{0.01, 0.25, 0.5, 0.75, 1} {
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"/>`+"\n",
+			lx+i*svgStep, ly, svgCell, svgCell, rgbHex(hoursRGB(scale, f*scale)))
+	}
+	fmt.Fprintf(&b, `<text x="%d" y="%d" %s>%.0fh</text>`+"\n", lx+5*svgStep+2, ly+9, svgFont, scale)
+	fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"/>`+"\n", lx+5*svgStep+26, ly, svgCell, svgCell, svgCyan)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" %s>%s</text>`+"\n", hx, ly+9, svgFont, html.EscapeString(holiday))
+
+	streak := i18n.T("Longest streak: 0 business days")
+	if s.Streak > 0 {
+		streak = i18n.T("Longest streak: %d business day(s), %s → %s",
+			s.Streak, s.StreakFrom.Format("2006-01-02"), s.StreakTo.Format("2006-01-02"))
+	}
+	for i, line := range []string{
+		i18n.T("Total logged:   %s over %d day(s)", i18n.Hours(s.Total), s.Logged),
+		i18n.T("Days missing:   %d (and %d below journey)", s.Missing, s.Partial),
+		streak,
+	} {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" %s>%s</text>`+"\n", svgLeft, gridBottom+18+i*14, svgFont, html.EscapeString(line))
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
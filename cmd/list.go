@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/IntraCLI/types"
 	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/tw"
 	"github.com/spf13/cobra"
@@ -16,66 +18,86 @@ import (
 
 var now = time.Now()
 
-var listFilterFlag string
+var (
+	listFilterFlag string
+	listFromFlag   string
+	listToFlag     string
+)
 
 func init() {
 	listTimesheetsCmd.Flags().IntVarP(&calYear, "year", "y", now.Year(), "Year to show")
 	listTimesheetsCmd.Flags().IntVarP(&calMonth, "month", "m", int(now.Month()), "Month to show (1-12)")
 	listTimesheetsCmd.Flags().StringVar(&listFilterFlag, "filter", "", "Filter: raw qlvm query or @savedName")
+	listTimesheetsCmd.Flags().StringVar(&listFromFlag, "from", "", "List from this date (YYYY-MM-DD or token); overrides --year/--month")
+	listTimesheetsCmd.Flags().StringVar(&listToFlag, "to", "", "List up to this date (YYYY-MM-DD or token); overrides --year/--month")
 
 	listTimesheetsCmd.RegisterFlagCompletionFunc("filter", filterNameCompletionFunc)
 	rootCmd.AddCommand(listTimesheetsCmd)
 }
 
-var (
-	meses = [...]string{
-		"jan", "fev", "mar", "abr", "mai", "jun",
-		"jul", "ago", "set", "out", "nov", "dez",
-	}
-)
-
 var listTimesheetsCmd = &cobra.Command{
 	Use:   "list-timesheets",
 	Short: "List your timesheets for the current period",
-	Run: func(cmd *cobra.Command, args []string) {
-		timesheets, err := mantisClient.Timesheet.GetTimesheets(
-			mantisCtx, currentUserID, calYear, time.Month(calMonth),
-		)
-		if err != nil {
-			log.Fatalf("Error getting timesheets: %v", err)
-		}
+	Long: `Lists your timesheets for a timesheet period (--year/--month).
 
+When --from/--to are given, or the filter bounds the date (e.g.
+"date >= last-month"), every period the range touches is fetched instead.`,
+	Run: func(cmd *cobra.Command, args []string) {
 		currentProfileName := appConfig.DefaultProfile
 		if profileName != "" {
 			currentProfileName = profileName
 		}
 		profile, profileExists := appConfig.Profiles[currentProfileName]
 		if !profileExists {
-			log.Fatalf("Profile '%s' not found", currentProfileName)
+			log.Fatal(i18n.T("Profile '%s' not found", currentProfileName))
 		}
 
-		if listFilterFlag != "" {
-			query := resolveFilter(listFilterFlag, appConfig.SavedFilters)
-			timesheets, err = utils.Apply(query, timesheets, profile)
+		query := resolveFilter(listFilterFlag, appConfig.SavedFilters)
+		periodFlagsSet := cmd.Flags().Changed("year") || cmd.Flags().Changed("month")
+
+		var timesheets []mantis.TimesheetsResponse
+		var err error
+		periodLabel := i18n.T("the current period")
+
+		if listFromFlag != "" || listToFlag != "" || (!periodFlagsSet && hasDateRange(query, "", "")) {
+			r, err := planDateRange(query, listFromFlag, listToFlag)
 			if err != nil {
-				log.Fatalf("Filter error: %v", err)
+				log.Fatal(err)
+			}
+			timesheets, err = fetchTimesheetsInRange(mantisCtx, r, true)
+			if err != nil {
+				log.Fatal(i18n.T("Error getting timesheets: %v", err))
+			}
+			periodLabel = r.String()
+		} else {
+			timesheets, err = mantisClient.Timesheet.GetTimesheets(
+				mantisCtx, currentUserID, calYear, time.Month(calMonth),
+			)
+			if err != nil {
+				log.Fatal(i18n.T("Error getting timesheets: %v", err))
+			}
+
+			filename := fmt.Sprintf(cache.TimesheetsCacheFileName, currentUserID, calYear, time.Month(calMonth))
+			if err := cache.WriteToCache(filename, timesheets); err != nil {
+				log.Print(i18n.T("Warning: failed to write timesheets to cache: %v", err))
 			}
 		}
 
-		filename := fmt.Sprintf(cache.TimesheetsCacheFileName, currentUserID, calYear, time.Month(calMonth))
-		if err := cache.WriteToCache(filename, timesheets); err != nil {
-			log.Printf("Warning: failed to write timesheets to cache: %v", err)
+		if query != "" {
+			timesheets, err = utils.Apply(query, timesheets, profile)
+			if err != nil {
+				log.Fatal(i18n.T("Filter error: %v", err))
+			}
 		}
 
 		if len(timesheets) == 0 {
-			fmt.Println("No timesheets found for the current period.")
+			fmt.Println(i18n.T("No timesheets found for %s.", periodLabel))
 			return
 		}
 
-		fmt.Printf(
-			"Timesheets for user %s (%d) for the current period:\n\n",
-			currentUser.FullName, currentUserID,
-		)
+		fmt.Println(i18n.T("Timesheets for user %s (%d) for %s:",
+			currentUser.FullName, currentUserID, periodLabel))
+		fmt.Println()
 
 		table := tablewriter.NewTable(os.Stdout,
 			tablewriter.WithConfig(tablewriter.Config{
@@ -87,7 +109,8 @@ var listTimesheetsCmd = &cobra.Command{
 			}),
 		)
 
-		table.Header("ID", "TimesheetType", "Date", "Hours", "Description", "Ticket", "SalesOrder", "SalesOrderLine")
+		table.Header("ID", i18n.T("TimesheetType"), i18n.T("Date"), i18n.T("Hours"), i18n.T("Description"),
+			i18n.T("Ticket"), i18n.T("SalesOrder"), i18n.T("SalesOrderLine"))
 
 		for _, ts := range timesheets {
 			parsedDate, err := time.Parse("2006-01-02T15:04:05Z", ts.DateDoc)
@@ -98,14 +121,13 @@ var listTimesheetsCmd = &cobra.Command{
 			if !ok {
 				continue
 			}
-			mes := meses[int(parsedDate.Month())-1]
-			formatted := fmt.Sprintf("%d de %s %d", parsedDate.Day(), mes, parsedDate.Year())
+			formatted := i18n.ShortDate(parsedDate)
 
 			table.Append(
 				fmt.Sprintf("%d", ts.TimesheetID),
 				parsedType,
 				formatted,
-				fmt.Sprintf("%.2f", ts.Quantity),
+				i18n.Float(ts.Quantity, 2),
 				ts.Description,
 				ts.TicketNo,
 				ts.SalesOrder,
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/tui"
 
 	"github.com/Salvadego/mantis/mantis"
 	"github.com/spf13/cobra"
@@ -21,7 +23,7 @@ func init() {
 	listProjectsCmd.RegisterFlagCompletionFunc("project-number", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		cfg, err := config.InitializeConfig()
 		if err != nil {
-			log.Printf("Error loading config for completion: %v", err)
+			log.Print(i18n.T("Error loading config for completion: %v", err))
 			return nil, cobra.ShellCompDirectiveNoFileComp
 		}
 
@@ -32,10 +34,10 @@ func init() {
 
 		profile, ok := cfg.Profiles[currentProfileName]
 		if !ok {
-			log.Printf(
+			log.Print(i18n.T(
 				"Default profile '%s' not found for completion.",
 				cfg.DefaultProfile,
-			)
+			))
 			return nil, cobra.ShellCompDirectiveNoFileComp
 		}
 
@@ -74,17 +76,17 @@ var listProjectsCmd = &cobra.Command{
 	Long:  `Retrieves and displays all projects assigned to the employee configured in your profile, and optionally adds a project alias.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if currentUser.EmployeeCode == 0 {
-			log.Fatalf("Employee code not found. Please run 'intracli search-employee' to update your profile.")
+			log.Fatal(i18n.T("Employee code not found. Please run 'intracli search-employee' to update your profile."))
 		}
 
 		projects, err := mantisClient.Timesheet.GetProjectTimesheets(mantisCtx, currentUser.EmployeeCode)
 		if err != nil {
-			log.Fatalf("Error getting projects: %v", err)
+			log.Fatal(i18n.T("Error getting projects: %v", err))
 		}
 
 		if aliasName != "" {
 			if projectNumber == 0 {
-				log.Fatal("When using --alias, you must also provide --project-number.")
+				log.Fatal(i18n.T("When using --alias, you must also provide --project-number."))
 			}
 
 			var selectedProject *mantis.ProjectTimesheet
@@ -96,7 +98,7 @@ var listProjectsCmd = &cobra.Command{
 			}
 
 			if selectedProject == nil {
-				log.Fatalf("Project number %d not found in current project list", projectNumber)
+				log.Fatal(i18n.T("Project number %d not found in current project list", projectNumber))
 			}
 
 			currentProfileName := appConfig.DefaultProfile
@@ -106,7 +108,7 @@ var listProjectsCmd = &cobra.Command{
 
 			profile, ok := appConfig.Profiles[currentProfileName]
 			if !ok {
-				log.Fatalf("Profile '%s' not found in config", currentProfileName)
+				log.Fatal(i18n.T("Profile '%s' not found in config", currentProfileName))
 			}
 
 			newAlias := config.ProjectAlias{
@@ -124,24 +126,29 @@ var listProjectsCmd = &cobra.Command{
 
 			err = config.SaveConfig(appConfig)
 			if err != nil {
-				log.Fatalf("Failed to save config: %v", err)
+				log.Fatal(i18n.T("Failed to save config: %v", err))
 			}
 
-			fmt.Printf("Alias '%s' saved for project %d (%s).\n", aliasName, selectedProject.ProjectNumber, selectedProject.ProjectTitle)
+			fmt.Println(i18n.T("Alias '%s' saved for project %d (%s).", aliasName, selectedProject.ProjectNumber, selectedProject.ProjectTitle))
 			return
 		}
 
 		if len(projects) == 0 {
-			fmt.Println("No projects found for the current employee.")
+			fmt.Println(i18n.T("No projects found for the current employee."))
 			return
 		}
 
-		fmt.Printf("Projects for user %s (%d):\n", currentUser.FullName, currentUserID)
+		fmt.Println(i18n.T("Projects for user %s (%d):", currentUser.FullName, currentUserID))
 		fmt.Println("---------------------------------------------------------------------------------------------------------")
-		fmt.Printf("%-60s %-15s %-15s %-15s\n", "Project Title", "Project Number", "Project Item", "Needs Ticket")
+		fmt.Println(tui.Pad(i18n.T("Project Title"), 60) + " " + tui.Pad(i18n.T("Project Number"), 15) + " " +
+			tui.Pad(i18n.T("Project Item"), 15) + " " + i18n.T("Needs Ticket"))
 		fmt.Println("---------------------------------------------------------------------------------------------------------")
 		for _, p := range projects {
-			fmt.Printf("%-60.60s %-15d %-15d %-15t\n", p.ProjectTitle, p.ProjectNumber, p.EmployeeLineNumber, p.ProjectNeedTicket)
+			needsTicket := i18n.T("no")
+			if p.ProjectNeedTicket {
+				needsTicket = i18n.T("yes")
+			}
+			fmt.Printf("%s %-15d %-15d %s\n", tui.Pad(p.ProjectTitle, 60), p.ProjectNumber, p.EmployeeLineNumber, needsTicket)
 		}
 		fmt.Println("---------------------------------------------------------------------------------------------------------")
 	},
@@ -0,0 +1,475 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	profileDeleteYes      bool
+	profileExportOutput   string
+	profileImportName     string
+	profileImportStrategy string
+	profileImportTrust    bool
+)
+
+func init() {
+	profileDeleteCmd.Flags().BoolVarP(&profileDeleteYes, "yes", "y", false, "Do not ask for confirmation")
+	profileExportCmd.Flags().StringVarP(&profileExportOutput, "output", "o", "", "Write to this file instead of stdout")
+	profileImportCmd.Flags().StringVar(&profileImportName, "name", "", "Import under this name instead of the one in the file")
+	profileImportCmd.Flags().StringVar(&profileImportStrategy, "strategy", "ask", "Project alias conflicts: ask, keep or replace")
+	profileImportCmd.Flags().BoolVar(&profileImportTrust, "trust", false, "Take the file's credentialRef and baseURL without asking")
+
+	profileImportCmd.RegisterFlagCompletionFunc("strategy",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"ask", "keep", "replace"}, cobra.ShellCompDirectiveNoFileComp
+		})
+
+	for _, c := range []*cobra.Command{profileShowCmd, profileUseCmd, profileCopyCmd, profileRenameCmd, profileDeleteCmd, profileExportCmd} {
+		c.ValidArgsFunction = firstArgProfileCompletion
+		profileCmd.AddCommand(c)
+	}
+	profileCmd.AddCommand(profileListCmd, profileImportCmd)
+	rootCmd.AddCommand(profileCmd)
+}
+
+// profileFile is the document written by `profile export`: the profile
+// with its name.
+type profileFile struct {
+	Name           string `yaml:"name"`
+	config.Profile `yaml:",inline"`
+}
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage profiles",
+	Long: `List, switch, copy, rename and delete profiles, and move them between
+machines with export and import.
+
+Examples:
+  intracli profile list
+  intracli profile use work
+  intracli profile copy work work-2025
+  intracli profile export work > work.yaml
+  intracli profile import work.yaml --strategy keep`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List profiles; * marks the default",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(appConfig.Profiles) == 0 {
+			fmt.Println(i18n.T("No profiles. Create one with search-employee --create-profile."))
+			return
+		}
+		for _, name := range slices.Sorted(maps.Keys(appConfig.Profiles)) {
+			p := appConfig.Profiles[name]
+			mark := " "
+			if name == appConfig.DefaultProfile {
+				mark = utils.SuccessStyle.Sprint("*")
+			}
+			details := i18n.T("user %d, %s/day, %d alias(es)", p.UserID, i18n.Hours(p.DailyJourney), len(p.ProjectAliases))
+			if p.BaseURL != "" {
+				details += ", " + p.BaseURL
+			}
+			if p.RoleID != 0 {
+				details += i18n.T(", role %d", p.RoleID)
+			}
+			fmt.Printf("%s %-16s %-30s %s\n", mark, name, p.EmployeeName, utils.MutedStyle.Sprint(details))
+		}
+	},
+}
+
+var profileShowCmd = &cobra.Command{
+	Use:         "show [NAME]",
+	Short:       "Print a profile (the current one by default)",
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		name := profileArg(args)
+		data, err := marshalProfile(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Stdout.Write(data)
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:         "use NAME",
+	Short:       "Make NAME the default profile",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		if _, ok := appConfig.Profiles[name]; !ok {
+			log.Fatal(i18n.T("Profile '%s' not found.", name))
+		}
+		appConfig.DefaultProfile = name
+		if err := config.SaveConfig(appConfig); err != nil {
+			log.Fatal(i18n.T("Error saving config: %v", err))
+		}
+		fmt.Println(i18n.T("Default profile is now '%s'.", name))
+	},
+}
+
+var profileCopyCmd = &cobra.Command{
+	Use:         "copy SRC DST",
+	Short:       "Copy a profile under a new name",
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		src, dst := args[0], args[1]
+		p, ok := appConfig.Profiles[src]
+		if !ok {
+			log.Fatal(i18n.T("Profile '%s' not found.", src))
+		}
+		if _, exists := appConfig.Profiles[dst]; exists {
+			log.Fatal(i18n.T("Profile '%s' already exists.", dst))
+		}
+		appConfig.Profiles[dst] = cloneProfile(p)
+		if err := config.SaveConfig(appConfig); err != nil {
+			log.Fatal(i18n.T("Error saving config: %v", err))
+		}
+		fmt.Println(i18n.T("Copied profile '%s' to '%s'.", src, dst))
+	},
+}
+
+var profileRenameCmd = &cobra.Command{
+	Use:         "rename OLD NEW",
+	Short:       "Rename a profile",
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		old, name := args[0], args[1]
+		p, ok := appConfig.Profiles[old]
+		if !ok {
+			log.Fatal(i18n.T("Profile '%s' not found.", old))
+		}
+		if _, exists := appConfig.Profiles[name]; exists {
+			log.Fatal(i18n.T("Profile '%s' already exists.", name))
+		}
+		delete(appConfig.Profiles, old)
+		appConfig.Profiles[name] = p
+		if appConfig.DefaultProfile == old {
+			appConfig.DefaultProfile = name
+		}
+		if err := config.SaveConfig(appConfig); err != nil {
+			log.Fatal(i18n.T("Error saving config: %v", err))
+		}
+		fmt.Println(i18n.T("Renamed profile '%s' to '%s'.", old, name))
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:         "delete NAME",
+	Short:       "Delete a profile",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		if _, ok := appConfig.Profiles[name]; !ok {
+			log.Fatal(i18n.T("Profile '%s' not found.", name))
+		}
+		if name == appConfig.DefaultProfile {
+			log.Fatal(i18n.T("'%s' is the default profile. Switch with 'intracli profile use' first.", name))
+		}
+		if !profileDeleteYes && !confirm(i18n.T("Delete profile '%s'?", name)) {
+			fmt.Println(i18n.T("Aborted."))
+			return
+		}
+		delete(appConfig.Profiles, name)
+		if err := config.SaveConfig(appConfig); err != nil {
+			log.Fatal(i18n.T("Error saving config: %v", err))
+		}
+		fmt.Println(i18n.T("Deleted profile '%s'.", name))
+	},
+}
+
+var profileExportCmd = &cobra.Command{
+	Use:         "export [NAME]",
+	Short:       "Write a profile as YAML (the current one by default)",
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		name := profileArg(args)
+		data, err := marshalProfile(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if profileExportOutput == "" {
+			os.Stdout.Write(data)
+			return
+		}
+		if err := os.WriteFile(profileExportOutput, data, 0644); err != nil {
+			log.Fatal(i18n.T("Failed to write %s: %v", profileExportOutput, err))
+		}
+		fmt.Println(i18n.T("Wrote %s", profileExportOutput))
+	},
+}
+
+var profileImportCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Add or merge a profile written by export",
+	Long: `Reads a profile written by 'intracli profile export' and checks its
+employee against Mantis. A new name adds the profile. An existing one takes
+the file's settings and merges the project aliases; aliases defined
+differently on both sides are resolved by --strategy:
+
+  ask      prompt for each conflict (default)
+  keep     keep the existing alias
+  replace  use the alias from the file
+
+A credentialRef can run commands and the baseURL receives your Mantis
+credentials, so new values for them are shown and only saved once
+confirmed, or with --trust.
+
+Examples:
+  intracli profile import work.yaml
+  intracli profile import work.yaml --name work-laptop --strategy replace`,
+	Args: cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"yaml", "yml"}, cobra.ShellCompDirectiveFilterFileExt
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		switch profileImportStrategy {
+		case "ask", "keep", "replace":
+		default:
+			log.Fatal(i18n.T("Invalid --strategy %q: expected ask, keep or replace", profileImportStrategy))
+		}
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		var in profileFile
+		if err := yaml.Unmarshal(data, &in); err != nil {
+			log.Fatal(i18n.T("Invalid profile file %s: %v", args[0], err))
+		}
+		name := in.Name
+		if profileImportName != "" {
+			name = profileImportName
+		}
+		if name == "" {
+			log.Fatal(i18n.T("The file has no profile name; pass --name."))
+		}
+
+		existing, exists := appConfig.Profiles[name]
+		guardProfileSecrets(&in.Profile, existing)
+
+		if err := verifyProfileEmployee(in.Profile); err != nil {
+			log.Fatal(err)
+		}
+		if !exists {
+			if in.ProjectAliases == nil {
+				in.ProjectAliases = map[string]config.ProjectAlias{}
+			}
+			appConfig.Profiles[name] = in.Profile
+		} else {
+			merged, err := mergeProjectAliases(existing.ProjectAliases, in.ProjectAliases, profileImportStrategy)
+			if err != nil {
+				log.Fatal(err)
+			}
+			in.ProjectAliases = merged
+			appConfig.Profiles[name] = in.Profile
+		}
+
+		if err := config.SaveConfig(appConfig); err != nil {
+			log.Fatal(i18n.T("Error saving config: %v", err))
+		}
+		if exists {
+			fmt.Println(i18n.T("Merged '%s' into profile '%s'.", args[0], name))
+		} else {
+			fmt.Println(i18n.T("Imported profile '%s'.", name))
+		}
+	},
+}
+
+// guardProfileSecrets keeps the credentialRef and baseURL of current when
+// in changes them and the user does not accept the new values: the first
+// can run commands and the second receives the Mantis credentials.
+func guardProfileSecrets(in *config.Profile, current config.Profile) {
+	fields := []struct {
+		key     string
+		value   *string
+		current string
+	}{
+		{"credentialRef", &in.CredentialRef, current.CredentialRef},
+		{"baseURL", &in.BaseURL, current.BaseURL},
+	}
+	for _, f := range fields {
+		if *f.value == "" || *f.value == f.current {
+			continue
+		}
+		fmt.Println(i18n.T("The file sets %s: %s", f.key, *f.value))
+		if profileImportTrust || confirm(i18n.T("Save this %s?", f.key)) {
+			continue
+		}
+		*f.value = f.current
+		if f.current == "" {
+			fmt.Println(i18n.T("Leaving %s unset.", f.key))
+		} else {
+			fmt.Println(i18n.T("Keeping %s %s.", f.key, f.current))
+		}
+	}
+}
+
+// profileArg is the profile named in args, or the current one.
+func profileArg(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	if profileName != "" {
+		return profileName
+	}
+	return appConfig.DefaultProfile
+}
+
+func marshalProfile(name string) ([]byte, error) {
+	p, ok := appConfig.Profiles[name]
+	if !ok {
+		return nil, i18n.Errorf("profile '%s' not found", name)
+	}
+	return yaml.Marshal(profileFile{Name: name, Profile: p})
+}
+
+// cloneProfile copies p without sharing its maps and slices.
+func cloneProfile(p config.Profile) config.Profile {
+	p.ProjectAliases = maps.Clone(p.ProjectAliases)
+	if p.ProjectAliases == nil {
+		p.ProjectAliases = map[string]config.ProjectAlias{}
+	}
+	p.WatchedTickets = slices.Clone(p.WatchedTickets)
+	p.OpeningBalances = slices.Clone(p.OpeningBalances)
+	p.JourneyOverrides = slices.Clone(p.JourneyOverrides)
+	return p
+}
+
+// verifyProfileEmployee checks that the profile's employee exists in
+// Mantis and warns about details that differ from it. A profile on another
+// Mantis instance than the active one is not checked.
+func verifyProfileEmployee(p config.Profile) error {
+	active := appConfig.Profiles[activeProfileName(appConfig)]
+	if url := appConfig.BaseURLFor(p); url != appConfig.BaseURLFor(active) {
+		log.Print(i18n.T("Warning: not checking the employee, the profile uses another Mantis instance (%s)", url))
+		return nil
+	}
+
+	var (
+		emp mantis.Employee
+		err error
+	)
+	switch {
+	case p.UserID != 0:
+		emp, err = mantisClient.Employee.GetEmployeeById(mantisCtx, p.UserID)
+	case p.EmployeeName != "":
+		emp, err = mantisClient.Employee.GetEmployeeByName(mantisCtx, p.EmployeeName)
+	default:
+		return i18n.Errorf("the profile has neither userID nor employeeName")
+	}
+	if err != nil {
+		return i18n.Errorf("employee of the profile not found in Mantis: %w", err)
+	}
+
+	warn := func(field, have, want string) {
+		if have != "" && have != want {
+			log.Print(i18n.T("Warning: %s is %q in the file but %q in Mantis", field, have, want))
+		}
+	}
+	warn("employeeName", p.EmployeeName, emp.FullName)
+	warn("email", p.Email, emp.Email)
+	if p.EmployeeCode != 0 && p.EmployeeCode != emp.EmployeeCode {
+		log.Print(i18n.T("Warning: employeeCode is %d in the file but %d in Mantis", p.EmployeeCode, emp.EmployeeCode))
+	}
+	return nil
+}
+
+// mergeProjectAliases adds the incoming aliases to the existing ones. An
+// alias defined differently on both sides is resolved by strategy.
+func mergeProjectAliases(existing, incoming map[string]config.ProjectAlias, strategy string) (map[string]config.ProjectAlias, error) {
+	merged := maps.Clone(existing)
+	if merged == nil {
+		merged = map[string]config.ProjectAlias{}
+	}
+	if strategy == "ask" && !term.IsTerminal(int(os.Stdin.Fd())) {
+		for _, alias := range slices.Sorted(maps.Keys(incoming)) {
+			if old, ok := existing[alias]; ok && old != incoming[alias] {
+				return nil, i18n.Errorf("alias '%s' conflicts and stdin is not a terminal; pass --strategy keep or replace", alias)
+			}
+		}
+	}
+
+	for _, alias := range slices.Sorted(maps.Keys(incoming)) {
+		in := incoming[alias]
+		old, ok := existing[alias]
+		if !ok || old == in {
+			merged[alias] = in
+			continue
+		}
+		replace := strategy == "replace"
+		if strategy == "ask" {
+			fmt.Println(i18n.T("Alias '%s' differs:", alias))
+			fmt.Println(i18n.T("  existing: %s", describeAlias(old)))
+			fmt.Println(i18n.T("  file:     %s", describeAlias(in)))
+			replace = confirm(i18n.T("Replace with the file's?"))
+		}
+		if replace {
+			merged[alias] = in
+		}
+	}
+	return merged, nil
+}
+
+func describeAlias(a config.ProjectAlias) string {
+	s := i18n.T("sales order %d line %d", a.SalesOrder, a.SalesOrderLine)
+	if a.NeedsTicket {
+		s += i18n.T(", needs ticket")
+	}
+	if a.TargetShare != 0 {
+		s += i18n.T(", target %s%%", i18n.Float(a.TargetShare, 0))
+	}
+	if d := aliasDefaultsSummary(a); d != "" {
+		s += i18n.T(", defaults %s", d)
+	}
+	return s
+}
+
+// stdinLines is shared by every prompt, so buffered input meant for a
+// later question is not lost.
+var stdinLines = bufio.NewReader(os.Stdin)
+
+// confirm asks a yes/no question on stdin; anything but y/yes (or the
+// Portuguese s/sim) is no.
+func confirm(question string) bool {
+	fmt.Print(i18n.T("%s [y/N]: ", question))
+	line, _ := stdinLines.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes", "s", "sim":
+		return true
+	}
+	return false
+}
+
+// firstArgProfileCompletion completes a profile name as the first
+// argument.
+func firstArgProfileCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return profileNameCompletionFunc(cmd, args, toComplete)
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+)
+
+// Mantis timesheet periods close on the 25th: GetTimesheets(year, month)
+// returns entries from the 26th of the previous month up to the 25th.
+const periodCloseDay = 25
+
+// maxConcurrentFetches is the default number of periods requested at once.
+const maxConcurrentFetches = 4
+
+type dateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+func (r dateRange) contains(t time.Time) bool {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	return !day.Before(r.From) && !day.After(r.To)
+}
+
+// periods returns every timesheet period the range touches, in order.
+func (r dateRange) periods() []YearMonth {
+	first, last := periodOf(r.From), periodOf(r.To)
+
+	var out []YearMonth
+	for ym := first; ; ym = nextPeriod(ym) {
+		out = append(out, ym)
+		if ym == last {
+			break
+		}
+	}
+	return out
+}
+
+// calendarMonths returns every calendar month the range touches, in order.
+// Non-business days are kept per calendar month, not per period.
+func (r dateRange) calendarMonths() []YearMonth {
+	var out []YearMonth
+	for m := time.Date(r.From.Year(), r.From.Month(), 1, 0, 0, 0, 0, time.Local); !m.After(r.To); m = m.AddDate(0, 1, 0) {
+		out = append(out, YearMonth{Year: m.Year(), Month: m.Month()})
+	}
+	return out
+}
+
+func (r dateRange) String() string {
+	return fmt.Sprintf("%s → %s", r.From.Format("2006-01-02"), r.To.Format("2006-01-02"))
+}
+
+// periodOf returns the timesheet period a given day is booked under.
+func periodOf(t time.Time) YearMonth {
+	if t.Day() > periodCloseDay {
+		next := time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.Local)
+		return YearMonth{Year: next.Year(), Month: next.Month()}
+	}
+	return YearMonth{Year: t.Year(), Month: t.Month()}
+}
+
+func nextPeriod(ym YearMonth) YearMonth {
+	if ym.Month == time.December {
+		return YearMonth{Year: ym.Year + 1, Month: time.January}
+	}
+	return YearMonth{Year: ym.Year, Month: ym.Month + 1}
+}
+
+// periodRange returns the first and last day of a timesheet period.
+func periodRange(ym YearMonth) dateRange {
+	return dateRange{
+		From: time.Date(ym.Year, ym.Month-1, periodCloseDay+1, 0, 0, 0, 0, time.Local),
+		To:   time.Date(ym.Year, ym.Month, periodCloseDay, 0, 0, 0, 0, time.Local),
+	}
+}
+
+// parseRangeDate parses a --from/--to value. Besides YYYY-MM-DD it accepts
+// every token understood by utils.ExpandTokens (today, last-week, 7d-ago…).
+func parseRangeDate(value string) (time.Time, error) {
+	expanded := utils.ExpandTokens(value)
+	t, err := time.ParseInLocation("2006-01-02", expanded, time.Local)
+	if err != nil {
+		return time.Time{}, i18n.Errorf("invalid date %q: expected YYYY-MM-DD or a relative token", value)
+	}
+	return t, nil
+}
+
+// planDateRange decides which days a filter-driven command has to load.
+//
+// Explicit --from/--to values win. Missing sides are taken from the date
+// bounds of the (already resolved) query. A lone lower bound runs until
+// today, a lone upper bound starts at the beginning of its period, and when
+// nothing bounds the query the current period is used, as before.
+func planDateRange(query, fromFlag, toFlag string) (dateRange, error) {
+	var r dateRange
+	var err error
+
+	if fromFlag != "" {
+		if r.From, err = parseRangeDate(fromFlag); err != nil {
+			return dateRange{}, err
+		}
+	}
+	if toFlag != "" {
+		if r.To, err = parseRangeDate(toFlag); err != nil {
+			return dateRange{}, err
+		}
+	}
+
+	queryFrom, queryTo := utils.QueryDateBounds(query)
+	if r.From.IsZero() {
+		r.From = queryFrom
+	}
+	if r.To.IsZero() {
+		r.To = queryTo
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	switch {
+	case r.From.IsZero() && r.To.IsZero():
+		return periodRange(YearMonth{Year: now.Year(), Month: now.Month()}), nil
+	case r.From.IsZero():
+		r.From = periodRange(periodOf(r.To)).From
+	case r.To.IsZero():
+		r.To = today
+		if r.From.After(today) {
+			r.To = r.From
+		}
+	}
+
+	if r.From.After(r.To) {
+		return dateRange{}, i18n.Errorf("invalid range: %s is after %s",
+			r.From.Format("2006-01-02"), r.To.Format("2006-01-02"))
+	}
+	return r, nil
+}
+
+// hasDateRange reports whether a command should switch to range planning
+// instead of its single-period default.
+func hasDateRange(query, fromFlag, toFlag string) bool {
+	if fromFlag != "" || toFlag != "" {
+		return true
+	}
+	from, to := utils.QueryDateBounds(query)
+	return !from.IsZero() || !to.IsZero()
+}
+
+// fetchTimesheetsInRange loads every period the range touches through a
+// MonthFetcher and returns the merged entries that fall inside the range,
+// ordered by date. force skips cached periods.
+func fetchTimesheetsInRange(
+	ctx context.Context,
+	r dateRange,
+	force bool,
+) ([]mantis.TimesheetsResponse, error) {
+	periods := r.periods()
+	byPeriod, err := newMonthFetcher(force).Timesheets(ctx, periods)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]mantis.TimesheetsResponse, 0, len(periods))
+	for _, ym := range periods {
+		results = append(results, byPeriod[ym])
+	}
+	return mergeTimesheets(results, r), nil
+}
+
+// mergeTimesheets flattens per-period results, drops duplicates and
+// entries outside the range, and sorts by date.
+func mergeTimesheets(results [][]mantis.TimesheetsResponse, r dateRange) []mantis.TimesheetsResponse {
+	seen := map[int]bool{}
+	var merged []mantis.TimesheetsResponse
+	for _, batch := range results {
+		for _, ts := range batch {
+			if seen[ts.TimesheetID] {
+				continue
+			}
+			parsedDate, err := time.Parse(time.RFC3339, ts.DateDoc)
+			if err != nil || !r.contains(parsedDate) {
+				continue
+			}
+			seen[ts.TimesheetID] = true
+			merged = append(merged, ts)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].DateDoc < merged[j].DateDoc })
+	return merged
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func testDay(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestPeriodOf(t *testing.T) {
+	tests := []struct {
+		day  string
+		want YearMonth
+	}{
+		{"2026-03-01", YearMonth{2026, time.March}},
+		{"2026-03-25", YearMonth{2026, time.March}},
+		{"2026-03-26", YearMonth{2026, time.April}},
+		{"2026-03-31", YearMonth{2026, time.April}},
+		{"2025-12-25", YearMonth{2025, time.December}},
+		{"2025-12-26", YearMonth{2026, time.January}},
+		{"2026-01-25", YearMonth{2026, time.January}},
+	}
+	for _, tt := range tests {
+		if got := periodOf(testDay(t, tt.day)); got != tt.want {
+			t.Errorf("periodOf(%s) = %v, want %v", tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestPeriodRange(t *testing.T) {
+	tests := []struct {
+		ym       YearMonth
+		from, to string
+	}{
+		{YearMonth{2026, time.March}, "2026-02-26", "2026-03-25"},
+		{YearMonth{2026, time.January}, "2025-12-26", "2026-01-25"},
+	}
+	for _, tt := range tests {
+		r := periodRange(tt.ym)
+		if !r.From.Equal(testDay(t, tt.from)) || !r.To.Equal(testDay(t, tt.to)) {
+			t.Errorf("periodRange(%v) = %s, want %s → %s", tt.ym, r, tt.from, tt.to)
+		}
+	}
+}
+
+func TestPlanDateRange(t *testing.T) {
+	defer func(old time.Time) { now = old }(now)
+	now = testDay(t, "2026-03-10")
+
+	tests := []struct {
+		name     string
+		query    string
+		from, to string
+		want     []YearMonth
+		wantErr  bool
+	}{
+		{
+			name:  "no bounds uses the current period",
+			query: "project = toyo",
+			want:  []YearMonth{{2026, time.March}},
+		},
+		{
+			name:  "close day stays in its period",
+			query: "date >= 2026-02-20 AND date <= 2026-02-25",
+			want:  []YearMonth{{2026, time.February}},
+		},
+		{
+			name:  "day after the close opens the next period",
+			query: "date >= 2026-02-25 AND date <= 2026-02-26",
+			want:  []YearMonth{{2026, time.February}, {2026, time.March}},
+		},
+		{
+			name: "december to january",
+			from: "2025-12-20",
+			to:   "2026-01-05",
+			want: []YearMonth{{2025, time.December}, {2026, time.January}},
+		},
+		{
+			name: "december 26th is booked in january",
+			from: "2025-12-26",
+			to:   "2025-12-31",
+			want: []YearMonth{{2026, time.January}},
+		},
+		{
+			name:  "lone lower bound runs until today",
+			query: "date >= 2026-01-26",
+			want:  []YearMonth{{2026, time.February}, {2026, time.March}},
+		},
+		{
+			name:  "flags win over the query",
+			query: "date >= 2025-01-01",
+			from:  "2026-03-01",
+			want:  []YearMonth{{2026, time.March}},
+		},
+		{
+			name:    "inverted range",
+			from:    "2026-03-10",
+			to:      "2026-03-01",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := planDateRange(tt.query, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("planDateRange error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := r.periods(); !slices.Equal(got, tt.want) {
+				t.Errorf("planDateRange(%q, %q, %q) = %s, periods %v, want %v",
+					tt.query, tt.from, tt.to, r, got, tt.want)
+			}
+		})
+	}
+}
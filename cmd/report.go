@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/spf13/cobra"
+)
+
+var reportOutputValues = []string{"table", "json", "csv"}
+
+var (
+	reportFrom   string
+	reportTo     string
+	reportFilter string
+	reportOutput string
+	reportForce  bool
+)
+
+func init() {
+	reportProjectsCmd.Flags().StringVar(&reportFrom, "from", "this-month", "Start date (YYYY-MM-DD or token)")
+	reportProjectsCmd.Flags().StringVar(&reportTo, "to", "today", "End date (YYYY-MM-DD or token)")
+	reportProjectsCmd.Flags().StringVar(&reportFilter, "filter", "", "Filter: raw qlvm query or @savedName")
+	reportProjectsCmd.Flags().StringVarP(&reportOutput, "output", "o", "table", "Output format: table|json|csv")
+	reportProjectsCmd.Flags().BoolVarP(&reportForce, "force", "f", false, "Force refresh instead of reading cached months")
+
+	reportProjectsCmd.RegisterFlagCompletionFunc("filter", filterNameCompletionFunc)
+	reportProjectsCmd.RegisterFlagCompletionFunc(
+		"output",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return reportOutputValues, cobra.ShellCompDirectiveNoFileComp
+		},
+	)
+
+	reportCmd.AddCommand(reportProjectsCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Reports built from timesheets and tickets",
+}
+
+// reportProjectsCmd shows where the logged hours went.
+//
+// Target allocations are declared per alias in the profile:
+//
+//	projectAliases:
+//	  toyo:
+//	    salesOrder: 101
+//	    salesOrderLine: 10
+//	    targetShare: 60
+var reportProjectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Distribution of logged hours by project, ticket and type",
+	Long: `Aggregates the hours logged between --from and --to by project alias (or
+project name when no alias matches), by ticket and by timesheet type, and
+renders them as bar charts with percentages.
+
+Aliases that declare a 'targetShare' (percent) in the profile are compared
+against their actual share.
+
+Examples:
+  intracli report projects
+  intracli report projects --from last-month --to this-month
+  intracli report projects --from 2026-01-01 --output csv > hours.csv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := getCurrentProfile(appConfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		from, err := parseRangeDate(reportFrom)
+		if err != nil {
+			log.Fatal(err)
+		}
+		to, err := parseRangeDate(reportTo)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if from.After(to) {
+			log.Fatal(i18n.T("Invalid range: %s is after %s", from.Format("2006-01-02"), to.Format("2006-01-02")))
+		}
+		r := dateRange{From: from, To: to}
+
+		timesheets, err := fetchTimesheetsInRange(mantisCtx, r, reportForce)
+		if err != nil {
+			log.Fatal(i18n.T("Error getting timesheets: %v", err))
+		}
+
+		if reportFilter != "" {
+			query := resolveFilter(reportFilter, appConfig.SavedFilters)
+			if timesheets, err = utils.Apply(query, timesheets, profile); err != nil {
+				log.Fatal(i18n.T("Filter error: %v", err))
+			}
+		}
+
+		report := utils.BuildProjectReport(
+			timesheets, profile, from.Format("2006-01-02"), to.Format("2006-01-02"),
+		)
+
+		switch reportOutput {
+		case "json":
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				log.Fatal(i18n.T("Failed to encode report: %v", err))
+			}
+		case "csv":
+			if err := writeReportCSV(report); err != nil {
+				log.Fatal(i18n.T("Failed to write CSV: %v", err))
+			}
+		case "table":
+			renderProjectReport(report)
+		default:
+			log.Fatal(i18n.T("Invalid --output %q: expected table|json|csv", reportOutput))
+		}
+	},
+}
+
+const reportBarWidth = 30
+
+func renderProjectReport(report utils.ProjectReport) {
+	utils.TitleStyle.Println(i18n.T("Hours from %s to %s: %s", report.From, report.To, i18n.Hours(report.Total)))
+	if report.Total == 0 {
+		fmt.Println(i18n.T("No timesheets found in this range."))
+	}
+
+	sections := []struct {
+		title string
+		rows  []utils.ReportRow
+	}{
+		{i18n.T("Projects"), report.Projects},
+		{i18n.T("Tickets"), report.Tickets},
+		{i18n.T("Types"), report.Types},
+	}
+
+	for _, section := range sections {
+		if len(section.rows) == 0 {
+			continue
+		}
+
+		width := 0
+		for _, row := range section.rows {
+			width = max(width, len([]rune(row.Key)))
+		}
+		width = min(width, 30)
+
+		fmt.Println()
+		utils.SectionStyle.Printf("■ %s ", section.title)
+		utils.MutedStyle.Printf("(%d)\n\n", len(section.rows))
+
+		for _, row := range section.rows {
+			fmt.Printf("  %-*s %s %8s %6s",
+				width, truncate(row.Key, width),
+				utils.Bar(row.Percent/100, reportBarWidth),
+				i18n.Hours(row.Hours), i18n.Float(row.Percent, 1)+"%",
+			)
+			if row.Target > 0 {
+				fmt.Printf("  %s", formatTargetDiff(row.Percent, row.Target))
+			}
+			fmt.Println()
+		}
+	}
+}
+
+// formatTargetDiff colors the gap between actual and target share: green
+// within 5 points, yellow within 15, red beyond.
+func formatTargetDiff(actual, target float64) string {
+	diff := actual - target
+	signed := i18n.Float(diff, 1)
+	if diff >= 0 {
+		signed = "+" + signed
+	}
+	text := i18n.T("target %s%% (%s)", i18n.Float(target, 1), signed)
+	switch {
+	case diff < 5 && diff > -5:
+		return utils.SlaGood.Sprint(text)
+	case diff < 15 && diff > -15:
+		return utils.SlaWarn.Sprint(text)
+	default:
+		return utils.SlaBad.Sprint(text)
+	}
+}
+
+func writeReportCSV(report utils.ProjectReport) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write([]string{"section", "key", "hours", "percent", "entries", "target"}); err != nil {
+		return err
+	}
+
+	sections := []struct {
+		name string
+		rows []utils.ReportRow
+	}{
+		{"project", report.Projects},
+		{"ticket", report.Tickets},
+		{"type", report.Types},
+	}
+	for _, section := range sections {
+		for _, row := range section.rows {
+			record := []string{
+				section.name,
+				row.Key,
+				fmt.Sprintf("%.2f", row.Hours),
+				fmt.Sprintf("%.2f", row.Percent),
+				fmt.Sprintf("%d", row.Entries),
+				fmt.Sprintf("%.2f", row.Target),
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func truncate(s string, width int) string {
+	r := []rune(strings.ReplaceAll(s, "\n", " "))
+	if len(r) <= width {
+		return string(r)
+	}
+	return string(r[:width-1]) + "…"
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/tui"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+// ticketTrendLimit bounds the snapshots kept per report.
+const ticketTrendLimit = 365
+
+var (
+	ticketReportType      string
+	ticketReportContract  string
+	ticketReportFilter    string
+	ticketReportOutput    string
+	ticketReportAll       bool
+	ticketReportForce     bool
+	ticketReportTrendDays int
+)
+
+func init() {
+	reportTicketsCmd.Flags().StringVar(&ticketReportType, "type", "", "Filter by ticket type (default: profile LType)")
+	reportTicketsCmd.Flags().StringVar(&ticketReportContract, "contract", "", "Filter by contract ID")
+	reportTicketsCmd.Flags().StringVarP(&ticketReportFilter, "filter", "F", "", "Filter: raw qlvm query or @savedName")
+	reportTicketsCmd.Flags().StringVarP(&ticketReportOutput, "output", "o", "table", "Output format: table|json")
+	reportTicketsCmd.Flags().BoolVar(&ticketReportAll, "all", false, "Include closed tickets")
+	reportTicketsCmd.Flags().BoolVarP(&ticketReportForce, "force", "f", false, "Refresh the report instead of reading the cache")
+	reportTicketsCmd.Flags().IntVar(&ticketReportTrendDays, "trend-days", 7, "Compare against the snapshot at least this many days old")
+
+	reportTicketsCmd.RegisterFlagCompletionFunc("contract", contracsCompletion)
+	reportTicketsCmd.RegisterFlagCompletionFunc("filter", filterTicketsNameCompletionFunc)
+	reportTicketsCmd.RegisterFlagCompletionFunc(
+		"output",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"table", "json"}, cobra.ShellCompDirectiveNoFileComp
+		},
+	)
+
+	reportCmd.AddCommand(reportTicketsCmd)
+}
+
+var reportTicketsCmd = &cobra.Command{
+	Use:   "tickets",
+	Short: "Ticket aging and SLA analytics",
+	Long: `Analyses the tickets of the dashboard report: how old they are by status
+and by priority, the median and 90th percentile age, how their SLA is
+distributed, and how many breached it (0% left) or are at risk (below 50%).
+
+Every run stores a snapshot of the headline numbers (one per day), and the
+report is compared against the snapshot at least --trend-days old, or the
+oldest one available, to show whether the backlog is growing.
+
+Closed tickets are left out unless --all is given.
+
+Examples:
+  intracli report tickets
+  intracli report tickets -f --trend-days 30
+  intracli report tickets --filter "priority <= 2" --output json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if ticketReportOutput != "table" && ticketReportOutput != "json" {
+			log.Fatal(i18n.T("Invalid --output %q: expected table|json", ticketReportOutput))
+		}
+
+		opts := newReportOptions(ticketReportType, ticketReportContract)
+		tickets, err := loadReport(opts, ticketReportForce)
+		if err != nil {
+			log.Fatal(i18n.T("Error getting tickets: %v", err))
+		}
+		query := resolveFilter(ticketReportFilter, appConfig.SavedTicketFilters)
+		if tickets, err = utils.ApplyTickets(query, tickets); err != nil {
+			log.Fatal(i18n.T("invalid --filter: %v", err))
+		}
+		if !ticketReportAll {
+			open := tickets[:0:0]
+			for _, t := range tickets {
+				if !utils.IsClosedStatus(t.Status) {
+					open = append(open, t)
+				}
+			}
+			tickets = open
+		}
+
+		now := time.Now()
+		report := utils.BuildTicketReport(tickets, now)
+
+		trendFile := fmt.Sprintf(cache.TicketTrendCacheFileName, ticketTrendKey(opts, query, ticketReportAll))
+		history, _ := cache.ReadFromCache[utils.TicketSnapshot](trendFile)
+		report.Baseline = utils.TrendBaseline(history, now, time.Duration(ticketReportTrendDays)*24*time.Hour)
+		history = utils.AddSnapshot(history, report.TicketSnapshot, ticketTrendLimit)
+		if err := cache.WriteToCache(trendFile, history); err != nil {
+			log.Print(i18n.T("Warning: failed to write to cache: %v", err))
+		}
+
+		if ticketReportOutput == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				log.Fatal(i18n.T("Failed to encode report: %v", err))
+			}
+			return
+		}
+		if err := renderTicketReport(report); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// ticketTrendKey identifies the ticket set a snapshot describes, so trends
+// only compare like with like.
+func ticketTrendKey(opts *mantis.GetReportOptions, query string, all bool) string {
+	sum := sha1.Sum([]byte(opts.Signature() + "|" + query + "|" + strconv.FormatBool(all)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func renderTicketReport(r utils.TicketReport) error {
+	utils.TitleStyle.Print(i18n.T("%d ticket(s)", r.Total))
+	if r.Total == 0 {
+		fmt.Println()
+		fmt.Println(i18n.T("No tickets found."))
+		renderTicketTrend(r)
+		return nil
+	}
+	fmt.Println(i18n.T("  median age %s  p90 %s", formatDays(r.MedianDays), formatDays(r.P90Days)))
+	fmt.Println(i18n.T("%s breached  %s at risk",
+		utils.SlaBad.Sprint(r.Breached), utils.SlaWarn.Sprint(r.AtRisk)))
+	renderTicketTrend(r)
+
+	for _, section := range []struct {
+		title, key string
+		rows       []utils.AgeRow
+	}{
+		{i18n.T("Age by status"), i18n.T("STATUS"), r.ByStatus},
+		{i18n.T("Age by priority"), i18n.T("PRIORITY"), r.ByPriority},
+	} {
+		fmt.Println()
+		utils.SectionStyle.Printf("■ %s\n\n", section.title)
+
+		table := tablewriter.NewTable(os.Stdout,
+			tablewriter.WithConfig(tablewriter.Config{
+				// Keep the bucket labels as written.
+				Header: tw.CellConfig{
+					Formatting: tw.CellFormatting{AutoFormat: tw.Off},
+				},
+				Row: tw.CellConfig{
+					Formatting: tw.CellFormatting{AutoWrap: tw.WrapNone},
+					Alignment: tw.CellAlignment{
+						Global:    tw.AlignRight,
+						PerColumn: []tw.Align{tw.AlignLeft},
+					},
+				},
+			}),
+		)
+		header := []any{section.key}
+		for _, b := range r.Buckets {
+			header = append(header, b.Label)
+		}
+		table.Header(append(header, i18n.T("TOTAL"), i18n.T("MEDIAN"))...)
+
+		for _, row := range section.rows {
+			cells := []any{row.Key}
+			for _, n := range row.Counts {
+				cell := ""
+				if n > 0 {
+					cell = strconv.Itoa(n)
+				}
+				cells = append(cells, cell)
+			}
+			cells = append(cells, row.Total, formatDays(row.MedianDays))
+			if err := table.Append(cells...); err != nil {
+				return err
+			}
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	utils.SectionStyle.Printf("■ SLA\n\n")
+	styles := []func(a ...any) string{
+		utils.SlaBad.Sprint, utils.SlaBad.Sprint, utils.SlaWarn.Sprint, utils.SlaGood.Sprint,
+	}
+	for i, row := range r.SLA {
+		fmt.Printf("  %s %s %4d %5s%%\n",
+			tui.Pad(i18n.T(row.Band), 17), styles[i](utils.Bar(row.Percent/100, reportBarWidth)), row.Count, i18n.Float(row.Percent, 1))
+	}
+	return nil
+}
+
+// renderTicketTrend compares the report with its baseline snapshot. Every
+// metric is better when lower, so increases are red.
+func renderTicketTrend(r utils.TicketReport) {
+	b := r.Baseline
+	if b == nil {
+		utils.MutedStyle.Println(i18n.T("No earlier snapshot to compare with yet."))
+		return
+	}
+
+	utils.MutedStyle.Print(i18n.T("Since %s (%s):", b.Time.Format("2006-01-02"), humanizeTime(b.Time)))
+	for _, m := range []struct {
+		name     string
+		old, new float64
+		days     bool
+	}{
+		{i18n.T("tickets"), float64(b.Total), float64(r.Total), false},
+		{i18n.T("breached"), float64(b.Breached), float64(r.Breached), false},
+		{i18n.T("at risk"), float64(b.AtRisk), float64(r.AtRisk), false},
+		{i18n.T("median"), b.MedianDays, r.MedianDays, true},
+	} {
+		diff := m.new - m.old
+		text := fmt.Sprintf("%+.0f", diff)
+		if m.days {
+			text = formatSignedDays(diff)
+		}
+		switch {
+		case diff > 0:
+			text = utils.SlaBad.Sprint(text)
+		case diff < 0:
+			text = utils.SlaGood.Sprint(text)
+		default:
+			text = utils.MutedStyle.Sprint(text)
+		}
+		fmt.Printf("  %s %s", m.name, text)
+	}
+	fmt.Println()
+}
+
+func formatDays(d float64) string {
+	return i18n.Float(d, 1) + "d"
+}
+
+func formatSignedDays(d float64) string {
+	if d >= 0 {
+		return "+" + formatDays(d)
+	}
+	return formatDays(d)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/mantis/mantis"
 	"github.com/spf13/cobra"
 )
@@ -19,7 +20,7 @@ func init() {
 		"modify",
 		"m",
 		false,
-		"Modify current profile configuration to the choosen roleID",
+		"Save the chosen role in the current profile",
 	)
 
 	rootCmd.AddCommand(rolesCmd)
@@ -34,60 +35,55 @@ var rolesCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		userRoles, err := showUserRoles(currentUserID)
 		if err != nil {
-			log.Fatalf("Error getting user roles: %v", err)
+			log.Fatal(i18n.T("Error getting user roles: %v", err))
 		}
 
 		if !modifyProfile {
 			return
 		}
 
-		currentProfileName := appConfig.DefaultProfile
-		if profileName != "" {
-			currentProfileName = profileName
-		}
+		currentProfileName := activeProfileName(appConfig)
 
 		profile, ok := appConfig.Profiles[currentProfileName]
 		if !ok {
-			log.Fatalf("Profile '%s' not found in config", currentProfileName)
+			log.Fatal(i18n.T("Profile '%s' not found in config", currentProfileName))
 		}
 		selectedRole, err := chooseUserRole(userRoles)
 		if err != nil {
-			log.Fatalf("Error getting user role: %v", err)
+			log.Fatal(i18n.T("Error getting user role: %v", err))
 		}
 
 		roleId := strconv.Itoa(int(selectedRole.ADRoleID))
 		mantisClient.SetRoleID(roleId)
-		appConfig.RoleID = int(selectedRole.ADRoleID)
-
-		log.Println(currentProfileName, profile)
+		profile.RoleID = int(selectedRole.ADRoleID)
 		appConfig.Profiles[currentProfileName] = profile
-		log.Println(appConfig)
 		err = config.SaveConfig(appConfig)
 
 		if err != nil {
-			log.Fatalf("Failed to save config: %v", err)
+			log.Fatal(i18n.T("Failed to save config: %v", err))
 		}
 
-		fmt.Printf(
-			"RoleID '%s' (%d) saved in config\n",
+		fmt.Println(i18n.T(
+			"RoleID '%s' (%d) saved in profile '%s'",
 			selectedRole.Name,
 			selectedRole.ADRoleID,
-		)
+			currentProfileName,
+		))
 	},
 }
 
 func showUserRoles(userId int) ([]mantis.UserRole, error) {
 	userRoles, err := mantisClient.GetUserRoles(mantisCtx, userId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve user roles: %w", err)
+		return nil, i18n.Errorf("failed to retrieve user roles: %w", err)
 	}
 
 	if len(userRoles) == 0 {
-		return nil, fmt.Errorf("no roles available for user %d", userId)
+		return nil, i18n.Errorf("no roles available for user %d", userId)
 	}
 
 	for i, role := range userRoles {
-		fmt.Printf("%d. %s (ID: %d)\n", i+1, role.Name, role.ADRoleID)
+		fmt.Println(i18n.T("%d. %s (ID: %d)", i+1, role.Name, role.ADRoleID))
 	}
 
 	return userRoles, nil
@@ -98,10 +94,10 @@ func chooseUserRole(userRoles []mantis.UserRole) (mantis.UserRole, error) {
 	var chosenIndex int
 
 	for {
-		fmt.Print("Enter the number of your chosen role: ")
+		fmt.Print(i18n.T("Enter the number of your chosen role: "))
 		_, err := fmt.Scanln(&choiceStr)
 		if err != nil {
-			return mantis.UserRole{}, fmt.Errorf("error reading choice: %w", err)
+			return mantis.UserRole{}, i18n.Errorf("error reading choice: %w", err)
 		}
 
 		choiceStr = strings.TrimSpace(choiceStr)
@@ -111,10 +107,10 @@ func chooseUserRole(userRoles []mantis.UserRole) (mantis.UserRole, error) {
 			chosenIndex > len(userRoles)
 
 		if isInvalidChoice {
-			fmt.Printf(
-				"Invalid choice. Please enter a number between 1 and %d.\n",
+			fmt.Println(i18n.T(
+				"Invalid choice. Please enter a number between 1 and %d.",
 				len(userRoles),
-			)
+			))
 			continue
 		}
 		break
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 
+	"github.com/Salvadego/IntraCLI/cache"
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
 	"github.com/fatih/color"
 
 	"github.com/spf13/cobra"
@@ -32,6 +37,10 @@ var rootCmd = &cobra.Command{
 			return nil
 		}
 
+		if isOfflineCmd(cmd) {
+			return initOfflineConfig()
+		}
+
 		if cmd.Name() != "intracli" {
 			return initCommonMantisClient(cmd)
 		}
@@ -49,9 +58,105 @@ func isCompletionCmd(cmd *cobra.Command) bool {
 	return false
 }
 
+// offlineAnnotation marks commands that only read local data: they load
+// the config but never authenticate against Mantis.
+const offlineAnnotation = "intracli.offline"
+
+// newSignalContext returns a context the first Ctrl-C cancels. The signal
+// is released right after, so a second Ctrl-C kills the program even in
+// prompts and loops that never look at the context.
+func newSignalContext() context.Context {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	stopSignals = stop
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx
+}
+
+func isOfflineCmd(cmd *cobra.Command) bool {
+	_, ok := cmd.Annotations[offlineAnnotation]
+	return ok
+}
+
+func initOfflineConfig() error {
+	var err error
+	appConfig, err = config.InitializeConfig()
+	if err != nil {
+		return i18n.Errorf("loading config: %w", err)
+	}
+	mantisCtx = newSignalContext()
+	scopeCache(appConfig)
+	if err := applyLanguage(appConfig); err != nil {
+		return err
+	}
+	return applyTheme(appConfig)
+}
+
+// scopeCache points the cache at the active profile's Mantis instance.
+func scopeCache(cfg *config.Config) {
+	cache.SetScope(cacheScopeFor(cfg, cfg.Profiles[activeProfileName(cfg)]))
+}
+
+// cacheScopeFor is the base URL profile p keeps a separate cache for. A
+// profile on the configured instance shares the cache directory itself,
+// where installs before per-profile URLs kept their data.
+func cacheScopeFor(cfg *config.Config, p config.Profile) string {
+	if p.BaseURL == "" || p.BaseURL == cfg.BaseURL {
+		return ""
+	}
+	return p.BaseURL
+}
+
+// applyLanguage selects the locale from --lang, the profile's or the
+// config's language or the environment, in that order.
+func applyLanguage(cfg *config.Config) error {
+	explicit := langFlag
+	if explicit == "" && cfg != nil {
+		explicit = cfg.LanguageFor(cfg.Profiles[activeProfileName(cfg)])
+	}
+	tag, err := i18n.Detect(explicit)
+	if err != nil {
+		return err
+	}
+
+	// Mantis localizes the timesheet type names -T and alias defaults are
+	// keyed on, so it has its own setting.
+	mantisLanguage = i18n.DefaultTag
+	if cfg != nil && cfg.MantisLanguage != "" {
+		mantisLanguage = cfg.MantisLanguage
+	}
+	return i18n.Set(tag)
+}
+
+// applyTheme selects the color theme from --theme or the config, at the
+// configured or detected color depth.
+func applyTheme(cfg *config.Config) error {
+	name := themeFlag
+	if name == "" {
+		name = cfg.Theme
+	}
+	theme, err := utils.ResolveTheme(name, cfg.Themes)
+	if err != nil {
+		return err
+	}
+	depth := utils.DetectColorDepth()
+	if cfg.ColorDepth != "" {
+		if depth, err = utils.ParseColorDepth(cfg.ColorDepth); err != nil {
+			return err
+		}
+	}
+	return utils.ApplyTheme(theme, depth)
+}
+
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	err := rootCmd.Execute()
+	if stopSignals != nil {
+		stopSignals()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("Error: %v", err))
 		os.Exit(1)
 	}
 }
@@ -60,25 +165,49 @@ func isShellCompletion() bool {
 	return os.Getenv("COMP_LINE") != ""
 }
 
+var (
+	langFlag  string
+	themeFlag string
+
+	// mantisLanguage is the language Mantis answers in.
+	mantisLanguage = i18n.DefaultTag
+)
+
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&profileName, "profile", "P", "", "Profile to use (overrides default)")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "Language of dates, numbers and messages: "+strings.Join(i18n.Tags(), ", ")+" (default from config or LANG)")
+	rootCmd.PersistentFlags().StringVar(&themeFlag, "theme", "", "Color theme: default, light, colorblind or one from the config")
+	rootCmd.PersistentFlags().IntVar(&fetchConcurrency, "concurrency", 0, "Maximum parallel month fetches (default from config, or 4)")
 
-	rootCmd.RegisterFlagCompletionFunc("profile",
-		func(
-			cmd *cobra.Command,
-			args []string,
-			toComplete string,
-		) ([]string, cobra.ShellCompDirective) {
-			cfg, err := config.InitializeConfig()
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveNoFileComp
-			}
-			var profiles []string
-			for p := range cfg.Profiles {
-				if strings.HasPrefix(p, toComplete) {
-					profiles = append(profiles, p)
-				}
+	rootCmd.RegisterFlagCompletionFunc("lang",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return i18n.Tags(), cobra.ShellCompDirectiveNoFileComp
+		})
+	rootCmd.RegisterFlagCompletionFunc("theme",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			var custom map[string]config.Theme
+			if cfg, err := config.InitializeConfig(); err == nil {
+				custom = cfg.Themes
 			}
-			return profiles, cobra.ShellCompDirectiveNoFileComp
+			return utils.ThemeNames(custom), cobra.ShellCompDirectiveNoFileComp
 		})
+	rootCmd.RegisterFlagCompletionFunc("profile", profileNameCompletionFunc)
+}
+
+func profileNameCompletionFunc(
+	cmd *cobra.Command,
+	args []string,
+	toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.InitializeConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var profiles []string
+	for p := range cfg.Profiles {
+		if strings.HasPrefix(p, toComplete) {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, cobra.ShellCompDirectiveNoFileComp
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Salvadego/IntraCLI/cache"
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/mantis/mantis"
 
 	"github.com/spf13/cobra"
@@ -30,17 +31,17 @@ var searchEmployeeCmd = &cobra.Command{
 	Long:  `Searches for employees in Mantis by a given name or it's user ID. Useful for finding the exact 'employeeName' for your configuration profile.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if employeeNameSearch == "" {
-			log.Fatal("Error: Employee name to search for is required. Use --name (-n).")
+			log.Fatal(i18n.T("Error: Employee name to search for is required. Use --name (-n)."))
 		}
 
 		employee, err := mantisClient.Employee.GetEmployeeByName(mantisCtx, employeeNameSearch)
 		if err != nil {
-			fmt.Printf("No exact match found for '%s'. Attempting partial match or broader search logic...\n", employeeNameSearch)
+			fmt.Println(i18n.T("No exact match found for '%s'. Attempting partial match or broader search logic...", employeeNameSearch))
 
 			if strings.Contains(err.Error(), "não foi possível encontrar funcionário ativo") {
-				fmt.Printf("No active employee found matching '%s'. Please try a different name or part of the name.\n", employeeNameSearch)
+				fmt.Println(i18n.T("No active employee found matching '%s'. Please try a different name or part of the name.", employeeNameSearch))
 			} else {
-				log.Fatalf("Error searching for employee '%s': %v", employeeNameSearch, err)
+				log.Fatal(i18n.T("Error searching for employee '%s': %v", employeeNameSearch, err))
 			}
 
 			return
@@ -50,11 +51,11 @@ var searchEmployeeCmd = &cobra.Command{
 		if err != nil {
 			employees, err = mantisClient.Employee.GetEmployeeList(mantisCtx, supervisorID)
 			if err != nil {
-				log.Fatalf("Error getting employees: %v", err)
+				log.Fatal(i18n.T("Error getting employees: %v", err))
 			}
 			err = cache.WriteToCache(cache.EmployeeListCacheFileName, employees)
 			if err != nil {
-				log.Fatalf("Failed to write to cache: %v", err)
+				log.Fatal(i18n.T("Failed to write to cache: %v", err))
 			}
 		}
 
@@ -69,28 +70,23 @@ var searchEmployeeCmd = &cobra.Command{
 		if createProfileName != "" {
 			cfg, err := config.InitializeConfig()
 			if err != nil {
-				log.Fatalf("Error loading config: %v", err)
+				log.Fatal(i18n.T("Error loading config: %v", err))
 			}
 
 			if existing, exists := cfg.Profiles[createProfileName]; exists {
 
-				log.Printf("Profile '%s' already exists. Choose a different name.\n", createProfileName)
-				log.Println("Do you wish to update it? ")
-				fmt.Print("y/n: ")
-				var choice string
-				_, _ = fmt.Scanln(&choice)
-
-				if choice == "y" {
-					log.Println("Updating profile...")
+				log.Print(i18n.T("Profile '%s' already exists. Choose a different name.", createProfileName))
+				if confirm(i18n.T("Do you wish to update it?")) {
+					log.Println(i18n.T("Updating profile..."))
 					cfg.Profiles[createProfileName] = mergeProfile(existing, employee, S_Employee)
 					err = config.SaveConfig(cfg)
 					if err != nil {
-						log.Fatalf("Error saving config: %v", err)
+						log.Fatal(i18n.T("Error saving config: %v", err))
 					}
-					fmt.Printf("Profile '%s' updated successfully.\n", createProfileName)
+					fmt.Println(i18n.T("Profile '%s' updated successfully.", createProfileName))
 					return
 				} else {
-					log.Println("Aborting...")
+					log.Println(i18n.T("Aborting..."))
 					return
 				}
 			}
@@ -110,19 +106,19 @@ var searchEmployeeCmd = &cobra.Command{
 
 			err = config.SaveConfig(cfg)
 			if err != nil {
-				log.Fatalf("Error saving config: %v", err)
+				log.Fatal(i18n.T("Error saving config: %v", err))
 			}
 
-			fmt.Printf("Profile '%s' created successfully.\n", createProfileName)
+			fmt.Println(i18n.T("Profile '%s' created successfully.", createProfileName))
 		}
 
-		fmt.Printf("Found employee:\n")
-		fmt.Printf("  Full Name: %s\n", employee.FullName)
-		fmt.Printf("  Employee Code: %d\n", employee.EmployeeCode)
-		fmt.Printf("  User ID: %d\n", employee.UserID)
-		fmt.Printf("  SUser ID: %s\n", S_Employee.SUserID)
-		fmt.Printf("  Email: %s\n", employee.Email)
-		fmt.Printf("  Daily Journey: %.2f\n", employee.DailyJourney)
+		fmt.Println(i18n.T("Found employee:"))
+		fmt.Println(i18n.T("  Full Name: %s", employee.FullName))
+		fmt.Println(i18n.T("  Employee Code: %d", employee.EmployeeCode))
+		fmt.Println(i18n.T("  User ID: %d", employee.UserID))
+		fmt.Println(i18n.T("  SUser ID: %s", S_Employee.SUserID))
+		fmt.Println(i18n.T("  Email: %s", employee.Email))
+		fmt.Println(i18n.T("  Daily Journey: %.2f", employee.DailyJourney))
 	},
 }
 
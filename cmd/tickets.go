@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
-	"encoding/hex"
 	"fmt"
 	"io"
 	"log"
@@ -19,6 +16,7 @@ import (
 	"time"
 
 	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/IntraCLI/utils"
 	"github.com/Salvadego/mantis/mantis"
 	"github.com/olekukonko/tablewriter"
@@ -51,6 +49,10 @@ var (
 	fromStr                   string
 	toStr                     string
 	shouldDownloadAttachments bool
+	attachmentDir             string
+	attachmentMatch           string
+	listAttachments           bool
+	extractAttachments        bool
 	sortBy                    string
 	sortOrder                 string
 	humanDates                bool
@@ -58,6 +60,7 @@ var (
 	forceTickets              bool
 	forceColors               bool
 	inline                    bool
+	ticketFilter              string
 
 	// ticketTypeFilter is the --type flag for the tickets command.
 	// It defaults to profile.LType when empty (set at run time, not parse time).
@@ -69,12 +72,17 @@ func init() {
 	ticketsCmd.Flags().StringVar(&contractID, "contract", "", "Filter by contract ID")
 	ticketsCmd.Flags().StringVar(&fromStr, "from", "", "Filter change date from (RFC3339)")
 	ticketsCmd.Flags().StringVar(&toStr, "to", "", "Filter change date to (RFC3339)")
+	ticketsCmd.Flags().StringVarP(&ticketFilter, "filter", "F", "", "Filter: raw qlvm query or @savedName")
 	ticketsCmd.Flags().StringVar(&sortBy, "sort-by", "created", "Sort by: created|sla|priority|status|number")
 	ticketsCmd.Flags().StringVar(&sortOrder, "sort-order", "desc", "Sort order: asc|desc")
 	ticketsCmd.Flags().BoolVar(&humanDates, "human-dates", false, "Show dates as relative time (e.g. 3d ago)")
 
 	ticketsCmd.Flags().StringVarP(&ticket, "ticket", "t", "", "Inspect ticket details")
 	ticketsCmd.Flags().BoolVarP(&shouldDownloadAttachments, "attachment", "a", false, "Download ticket attachments")
+	ticketsCmd.Flags().StringVar(&attachmentDir, "dir", ".", "Directory to download attachments into")
+	ticketsCmd.Flags().StringVar(&attachmentMatch, "match", "", "Only attachments whose name matches this glob (e.g. '*.pdf')")
+	ticketsCmd.Flags().BoolVar(&listAttachments, "list", false, "List ticket attachments without downloading")
+	ticketsCmd.Flags().BoolVar(&extractAttachments, "extract", false, "Extract downloaded zip and tar archives")
 	ticketsCmd.Flags().BoolVarP(&hoursOnly, "hoursOnly", "H", false, "Show only project hours")
 	ticketsCmd.Flags().BoolVarP(&forceTickets, "force-tickets", "f", false, "Refresh tickets response")
 	ticketsCmd.Flags().BoolVarP(&inline, "inline", "i", false, "Display tickets inlined")
@@ -93,6 +101,7 @@ func init() {
 		},
 	)
 	ticketsCmd.RegisterFlagCompletionFunc("contract", contracsCompletion)
+	ticketsCmd.RegisterFlagCompletionFunc("filter", filterTicketsNameCompletionFunc)
 	ticketsCmd.RegisterFlagCompletionFunc("ticket", ticketCompletionFunc)
 
 	rootCmd.AddCommand(ticketsCmd)
@@ -101,64 +110,101 @@ func init() {
 var ticketsCmd = &cobra.Command{
 	Use:   "tickets",
 	Short: "List tickets from dashboard report",
+	Long: `Lists the tickets of the dashboard report grouped by status, or inspects
+a single ticket with -t.
+
+--filter takes a qlvm query (or @name of a query saved with
+'filter-tickets') over these fields:
+
+  number          string      e.g.  number = 8000123456
+  status          string      e.g.  status = 'Em Atendimento'
+  priority        int (rank)  e.g.  priority <= 2
+  sla             int (%)     e.g.  sla < 60
+  created         YYYY-MM-DD  e.g.  created >= '30d-ago'
+  contract        string      e.g.  contract = 4100123
+  contract_title  string      e.g.  #acme   (contains shorthand)
+  description     string      e.g.  .sefaz  (contains shorthand)
+
+Examples:
+  intracli tickets
+  intracli tickets --filter "sla < 60 AND priority <= 2"
+  intracli tickets --filter @urgent --sort-by sla --sort-order asc
+  intracli tickets -t 8000123456`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if ticket == "" {
 			return handleReports()
 		}
 
-		resp, err := mantisClient.Dashboard.GetSupportInfo(mantisCtx, ticket)
+		resp, err := fetchSupportInfo(ticket)
 		if err != nil {
 			return err
 		}
 
-		if shouldDownloadAttachments && len(resp.Attachments) > 0 {
-			for _, att := range resp.Attachments {
-				fmt.Printf("Downloading attachment: %s\n", att.FileName)
-				resp, err := mantisClient.Dashboard.GetSupportFile(mantisCtx, att)
-				if err != nil {
-					fmt.Printf("Error trying to download attachment [%s]: %v\n", att.FileName, err)
-					continue
-				}
-				if err = saveFile(att.FileName, resp.FileContent); err != nil {
-					fmt.Printf("Error saving attachment [%s]: %v\n", att.FileName, err)
-				}
+		if listAttachments || shouldDownloadAttachments {
+			atts, err := filterAttachments(resp.Attachments, attachmentMatch)
+			if err != nil {
+				return err
+			}
+			if listAttachments {
+				printAttachments(atts)
+				return nil
+			}
+			if len(atts) > 0 {
+				downloadAttachments(atts, attachmentDir, extractAttachments)
+				return nil
+			}
+			if attachmentMatch != "" {
+				return i18n.Errorf("no attachment matches %q", attachmentMatch)
 			}
-			return nil
 		}
 
 		if hoursOnly {
 			if resp.TotHrAprovadaPC != "" {
-				fmt.Printf("Total Approved Project: %s\n", resp.TotHrAprovadaPC)
-				fmt.Printf("Total Consumed Project: %s\n", resp.TotHrPC)
-				toHrPc, _ := strconv.ParseFloat(resp.TotHrPC, 64)
-				totHrAprovadaPC, _ := strconv.ParseFloat(resp.TotHrAprovadaPC, 64)
-				fmt.Printf("Total Disponible Project: %.2f\n", totHrAprovadaPC-toHrPc)
+				renderProjectHours(os.Stdout, &resp)
 			}
 			return nil
 		}
 
-		renderTicket(&resp)
+		renderTicket(os.Stdout, &resp, loadTicketEfforts())
 		return nil
 	},
 }
 
-func handleReports() error {
-	opts := &mantis.GetReportOptions{}
-
+// newReportOptions builds the dashboard report options of the current
+// profile. The ticket type defaults to the profile's LType when empty.
+func newReportOptions(ticketType, contract string) *mantis.GetReportOptions {
 	currentProfileName := appConfig.DefaultProfile
 	if profileName != "" {
 		currentProfileName = profileName
 	}
 	profile, _ := appConfig.Profiles[currentProfileName]
 
-	// --type defaults to the profile's LType when not explicitly set.
-	resolvedType := ticketTypeFilter
-	if resolvedType == "" {
-		resolvedType = profile.LType
+	if ticketType == "" {
+		ticketType = profile.LType
 	}
-	opts.FilterType = resolvedType
-	opts.FilterContractID = contractID
-	opts.FilterUserID = profile.SUserID
+	return &mantis.GetReportOptions{
+		FilterType:       ticketType,
+		FilterContractID: contract,
+		FilterUserID:     profile.SUserID,
+	}
+}
+
+// fetchSupportInfo gets the details of a ticket and caches them, so the
+// ticket becomes searchable offline with 'tickets search'.
+func fetchSupportInfo(ticketNo string) (mantis.SupportInfoResponse, error) {
+	resp, err := mantisClient.Dashboard.GetSupportInfo(mantisCtx, ticketNo)
+	if err != nil {
+		return resp, err
+	}
+	cacheFile := fmt.Sprintf(cache.SupportInfoCacheFileName, ticketNo)
+	if err := cache.WriteToCache(cacheFile, []mantis.SupportInfoResponse{resp}); err != nil {
+		log.Print(i18n.T("Warning: failed to write to cache: %v", err))
+	}
+	return resp, nil
+}
+
+func handleReports() error {
+	opts := newReportOptions(ticketTypeFilter, contractID)
 
 	if fromStr != "" {
 		t, err := time.Parse(time.RFC3339, fromStr)
@@ -176,35 +222,50 @@ func handleReports() error {
 		opts.ChangeAtTo = &t
 	}
 
-	ctx := context.Background()
-
-	sig := opts.Signature()
-	cacheFile := fmt.Sprintf(cache.TicketsCacheFileName, sig)
+	tickets, err := loadReport(opts, forceTickets)
+	if err != nil {
+		log.Fatal(i18n.T("Error getting tickets: %v", err))
+	}
 
-	tickets, err := cache.ReadFromCache[mantis.TicketResponse](cacheFile)
-	if err != nil || forceTickets {
-		tickets, err = mantisClient.Dashboard.GetReport(ctx, opts)
-		if err != nil {
-			log.Fatalf("Error getting tickets: %v", err)
-		}
-		if err := cache.WriteToCache(cacheFile, tickets); err != nil {
-			log.Fatalf("Failed to write to cache: %v", err)
-		}
+	tickets, err = utils.ApplyTickets(resolveFilter(ticketFilter, appConfig.SavedTicketFilters), tickets)
+	if err != nil {
+		return i18n.Errorf("invalid --filter: %w", err)
 	}
 
 	sortTickets(tickets, SortBy(sortBy), sortOrder)
 
+	efforts := loadTicketEfforts()
+
 	if inline {
-		for _, l := range ticketsToLines(tickets) {
+		for _, l := range ticketsToLines(tickets, efforts) {
 			fmt.Println(l)
 		}
 		return nil
 	}
 
-	return renderByStatus(tickets)
+	return renderByStatus(tickets, efforts)
 }
 
-func renderByStatus(tickets []mantis.TicketResponse) error {
+// loadReport returns the dashboard report for opts, from the cache unless
+// force is set or nothing is cached yet.
+func loadReport(opts *mantis.GetReportOptions, force bool) ([]mantis.TicketResponse, error) {
+	cacheFile := fmt.Sprintf(cache.TicketsCacheFileName, opts.Signature())
+
+	tickets, err := cache.ReadFromCache[mantis.TicketResponse](cacheFile)
+	if err == nil && !force {
+		return tickets, nil
+	}
+	tickets, err = mantisClient.Dashboard.GetReport(context.Background(), opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := cache.WriteToCache(cacheFile, tickets); err != nil {
+		log.Print(i18n.T("Warning: failed to write to cache: %v", err))
+	}
+	return tickets, nil
+}
+
+func renderByStatus(tickets []mantis.TicketResponse, efforts map[string]utils.TicketEffort) error {
 	groups := make(map[string][]mantis.TicketResponse)
 	for _, t := range tickets {
 		groups[t.Status] = append(groups[t.Status], t)
@@ -231,7 +292,8 @@ func renderByStatus(tickets []mantis.TicketResponse) error {
 			}),
 		)
 
-		table.Header("Ticket Number", "Priority", "Description", "SLA", "Created Date")
+		table.Header(i18n.T("Ticket Number"), i18n.T("Priority"), i18n.T("Description"), "SLA",
+			i18n.T("Created Date"), i18n.T("Logged"), i18n.T("Last Logged"))
 
 		for _, t := range groups[status] {
 			created := parseTime(t.TicketCreated).String()
@@ -244,6 +306,8 @@ func renderByStatus(tickets []mantis.TicketResponse) error {
 				t.Description,
 				colorSLA(t.PercSLA),
 				created,
+				formatEffort(efforts[t.TicketNumber]),
+				formatLastLogged(efforts[t.TicketNumber]),
 			)
 		}
 
@@ -255,19 +319,17 @@ func renderByStatus(tickets []mantis.TicketResponse) error {
 	return nil
 }
 
-func renderTicket(t *mantis.SupportInfoResponse) {
-	utils.TitleStyle.Printf("Ticket %s\n", t.ObjectID)
-	fmt.Println(strings.Repeat("─", 50))
-	fmt.Printf("%s: %s\n", utils.MutedStyle.Sprint("Status"), t.UserStatusDescription)
-	fmt.Printf("%s: %s\n", utils.MutedStyle.Sprint("Priority"), colorPriority(t.Priority))
-	fmt.Printf("Process Type: %s\n", t.ProcessType)
-	fmt.Printf("Category: %s\n", t.CategoryID)
+// renderTicket writes the details of a ticket to w, with the hours from
+// efforts (see loadTicketEfforts).
+func renderTicket(w io.Writer, t *mantis.SupportInfoResponse, efforts map[string]utils.TicketEffort) {
+	utils.TitleStyle.Fprintln(w, i18n.T("Ticket %s", t.ObjectID))
+	fmt.Fprintln(w, strings.Repeat("─", 50))
+	fmt.Fprintf(w, "%s: %s\n", utils.MutedStyle.Sprint(i18n.T("Status")), t.UserStatusDescription)
+	fmt.Fprintf(w, "%s: %s\n", utils.MutedStyle.Sprint(i18n.T("Priority")), colorPriority(t.Priority))
+	fmt.Fprintln(w, i18n.T("Process Type: %s", t.ProcessType))
+	fmt.Fprintln(w, i18n.T("Category: %s", t.CategoryID))
 	if t.TotHrAprovadaPC != "" {
-		fmt.Printf("Total Approved Project: %s\n", t.TotHrAprovadaPC)
-		fmt.Printf("Total Consumed Project: %s\n", t.TotHrPC)
-		toHrPc, _ := strconv.ParseFloat(t.TotHrPC, 64)
-		totHrAprovadaPC, _ := strconv.ParseFloat(t.TotHrAprovadaPC, 64)
-		fmt.Printf("Total Disponible Project: %.2f\n", totHrAprovadaPC-toHrPc)
+		renderProjectHours(w, t)
 	}
 
 	createdAt := t.CreatedAt.Format(time.RFC3339)
@@ -277,55 +339,70 @@ func renderTicket(t *mantis.SupportInfoResponse) {
 		changedAt = humanizeTime(t.ChangedAt)
 	}
 
-	fmt.Printf("Created At: %s\n", createdAt)
-	fmt.Printf("Changed At: %s\n\n", changedAt)
+	fmt.Fprintln(w, i18n.T("Created At: %s", createdAt))
+	fmt.Fprintln(w, i18n.T("Changed At: %s", changedAt))
+	if e, ok := efforts[t.ObjectID]; ok {
+		fmt.Fprintln(w, i18n.T("Logged by me: %s in %d entries, last on %s", i18n.Hours(e.Hours), e.Entries, formatLastLogged(e)))
+	}
+	fmt.Fprintln(w)
 
-	utils.SectionStyle.Println("Description")
-	fmt.Println(strings.Repeat("─", 30))
-	fmt.Println(t.Description)
-	fmt.Println()
+	utils.SectionStyle.Fprintln(w, i18n.T("Description"))
+	fmt.Fprintln(w, strings.Repeat("─", 30))
+	fmt.Fprintln(w, t.Description)
+	fmt.Fprintln(w)
 
 	if t.CreatedBy.Name != "" {
-		fmt.Println("Created By:")
-		fmt.Printf("%s <%s> | Phone: %s\n\n", t.CreatedBy.Name, t.CreatedBy.Email, t.CreatedBy.Phone)
+		fmt.Fprintln(w, i18n.T("Created By:"))
+		fmt.Fprintln(w, i18n.T("%s <%s> | Phone: %s", t.CreatedBy.Name, t.CreatedBy.Email, t.CreatedBy.Phone))
+		fmt.Fprintln(w)
 	}
 
 	if t.ProcessorDetail.Name != "" {
-		utils.TitleStyle.Println("Processor:")
-		utils.TitleStyle.Printf("%s <%s>\n\n", t.ProcessorDetail.Name, t.ProcessorDetail.Email)
+		utils.TitleStyle.Fprintln(w, i18n.T("Processor:"))
+		utils.TitleStyle.Fprintf(w, "%s <%s>\n\n", t.ProcessorDetail.Name, t.ProcessorDetail.Email)
 	}
 
 	sort.Slice(t.Texts, func(i, j int) bool {
 		return t.Texts[i].TDFCreatedAt.Before(t.Texts[j].TDFCreatedAt)
 	})
 
-	fmt.Println("--- Texts ---")
+	fmt.Fprintln(w, i18n.T("--- Texts ---"))
 	for _, tx := range t.Texts {
 		tsCreated := tx.TDFCreatedAt.Format("2006-01-02 15:04")
 		if humanDates {
 			tsCreated = humanizeTime(tx.TDFCreatedAt)
 		}
-		utils.MutedStyle.Printf("\n[%s]\n", tsCreated)
+		utils.MutedStyle.Fprintf(w, "\n[%s]\n", tsCreated)
 		displayName := tx.TDFUser
 		if tx.UserInformation != nil && tx.UserInformation.Name != "" {
 			displayName = tx.UserInformation.Name
 		}
-		utils.TitleStyle.Printf("[%s (%s)]\n", displayName, tx.TdID)
-		fmt.Println(formatTextBlock(stripHTML(tx.Text), 90))
-		fmt.Println()
+		utils.TitleStyle.Fprintf(w, "[%s (%s)]\n", displayName, tx.TdID)
+		fmt.Fprintln(w, formatTextBlock(stripHTML(tx.Text), 90))
+		fmt.Fprintln(w)
 	}
 
-	fmt.Printf("Created At: %s\n", t.CreatedAt.Format("2006-01-02 15:04"))
-	fmt.Printf("Changed At: %s\n\n", changedAt)
+	fmt.Fprintln(w, i18n.T("Created At: %s", t.CreatedAt.Format("2006-01-02 15:04")))
+	fmt.Fprintln(w, i18n.T("Changed At: %s", changedAt))
+	fmt.Fprintln(w)
 
 	if len(t.Attachments) > 0 {
-		fmt.Println("--- Attachments ---")
+		fmt.Fprintln(w, i18n.T("--- Attachments ---"))
 		for _, a := range t.Attachments {
-			fmt.Printf("%s by %s\n", a.FileName, a.CreatedBy.Name)
+			fmt.Fprintln(w, i18n.T("%s by %s", a.FileName, a.CreatedBy.Name))
 		}
 	}
 }
 
+// renderProjectHours writes the project hour budget of a ticket to w.
+func renderProjectHours(w io.Writer, t *mantis.SupportInfoResponse) {
+	fmt.Fprintln(w, i18n.T("Total Approved Project: %s", t.TotHrAprovadaPC))
+	fmt.Fprintln(w, i18n.T("Total Consumed Project: %s", t.TotHrPC))
+	consumed, _ := parseHours(t.TotHrPC)
+	approved, _ := parseHours(t.TotHrAprovadaPC)
+	fmt.Fprintln(w, i18n.T("Total Disponible Project: %s", i18n.Float(approved-consumed, 2)))
+}
+
 var htmlTagRe = regexp.MustCompile(`<[^>]*>`)
 var spaceRe = regexp.MustCompile(`\s{2,}`)
 var blankLineRe = regexp.MustCompile(`\n{3,}`)
@@ -361,46 +438,11 @@ func formatTextBlock(s string, width int) string {
 	return strings.TrimSpace(out)
 }
 
-func saveFile(filename, fileContentBase string) error {
-	data, err := decodeFileContent(fileContentBase)
-	if err != nil {
-		return err
-	}
-	out, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer out.Close()
-	if _, err = io.Copy(out, bytes.NewReader(data)); err != nil {
-		return err
-	}
-	fmt.Printf("Saved: %s\n", filename)
-	return nil
-}
-
-func decodeFileContent(s string) ([]byte, error) {
-	s = strings.TrimSpace(s)
-	s = strings.ReplaceAll(s, "\n", "")
-	s = strings.ReplaceAll(s, "\r", "")
-
-	isHex := len(s)%2 == 0
-	for _, r := range s {
-		if !((r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')) {
-			isHex = false
-			break
-		}
-	}
-	if isHex {
-		return hex.DecodeString(s)
-	}
-
-	if m := len(s) % 4; m != 0 {
-		s += strings.Repeat("=", 4-m)
-	}
-	if data, err := base64.StdEncoding.DecodeString(s); err == nil {
-		return data, nil
-	}
-	return base64.URLEncoding.DecodeString(s)
+// sortedTexts returns a copy of texts, oldest first.
+func sortedTexts(texts []mantis.Text) []mantis.Text {
+	out := append([]mantis.Text(nil), texts...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].TDFCreatedAt.Before(out[j].TDFCreatedAt) })
+	return out
 }
 
 func parseTime(s string) time.Time {
@@ -409,16 +451,6 @@ func parseTime(s string) time.Time {
 }
 
 func sortTickets(tickets []mantis.TicketResponse, by SortBy, order string) {
-	parseSLA := func(s string) int {
-		v, _ := strconv.Atoi(strings.TrimSuffix(s, "%"))
-		return v
-	}
-	priorityRank := func(p string) int {
-		parts := strings.Split(p, ":")
-		v, _ := strconv.Atoi(strings.TrimSpace(parts[0]))
-		return v
-	}
-
 	var less func(i, j int) bool
 	switch by {
 	case Created:
@@ -426,10 +458,12 @@ func sortTickets(tickets []mantis.TicketResponse, by SortBy, order string) {
 			return parseTime(tickets[i].TicketCreated).Before(parseTime(tickets[j].TicketCreated))
 		}
 	case Sla:
-		less = func(i, j int) bool { return parseSLA(tickets[i].PercSLA) < parseSLA(tickets[j].PercSLA) }
+		less = func(i, j int) bool {
+			return utils.ParsePercent(tickets[i].PercSLA) < utils.ParsePercent(tickets[j].PercSLA)
+		}
 	case Priority:
 		less = func(i, j int) bool {
-			return priorityRank(tickets[i].Priority) < priorityRank(tickets[j].Priority)
+			return utils.PriorityRank(tickets[i].Priority) < utils.PriorityRank(tickets[j].Priority)
 		}
 	case Status:
 		less = func(i, j int) bool { return tickets[i].Status < tickets[j].Status }
@@ -472,7 +506,7 @@ func loadAndMergeCachedTickets() ([]mantis.TicketResponse, error) {
 	for _, name := range files {
 		tickets, err := cache.ReadFromCache[mantis.TicketResponse](name)
 		if err != nil {
-			log.Printf("Skipping cache file %s: %v", name, err)
+			log.Print(i18n.T("Skipping cache file %s: %v", name, err))
 			continue
 		}
 		for _, t := range tickets {
@@ -488,11 +522,12 @@ func loadAndMergeCachedTickets() ([]mantis.TicketResponse, error) {
 	return out, nil
 }
 
-func ticketsToLines(tickets []mantis.TicketResponse) []string {
+func ticketsToLines(tickets []mantis.TicketResponse, efforts map[string]utils.TicketEffort) []string {
 	const (
 		numW  = 10
 		prioW = 16
 		dateW = 12
+		logW  = 8
 		descW = 70
 	)
 
@@ -508,11 +543,17 @@ func ticketsToLines(tickets []mantis.TicketResponse) []string {
 			desc = desc[:descW-1] + "…"
 		}
 
+		logged := ""
+		if e, ok := efforts[t.TicketNumber]; ok {
+			logged = fmt.Sprintf("%.2fh", e.Hours)
+		}
+
 		lines = append(lines, fmt.Sprintf(
-			"%-*s  %-*s  %-*s  %-*s",
+			"%-*s  %-*s  %-*s  %-*s  %-*s",
 			numW, utils.SuccessStyle.Sprint(t.TicketNumber),
 			prioW, colorPriority(t.Priority),
 			dateW, utils.MutedStyle.Sprint(created),
+			logW, logged,
 			descW, desc,
 		))
 	}
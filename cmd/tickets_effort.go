@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+var (
+	untouchedDays     int
+	untouchedFilter   string
+	untouchedType     string
+	untouchedContract string
+	untouchedAll      bool
+)
+
+func init() {
+	ticketsUntouchedCmd.Flags().IntVarP(&untouchedDays, "days", "d", 7, "Tickets with no time logged in this many days")
+	ticketsUntouchedCmd.Flags().StringVarP(&untouchedFilter, "filter", "F", "", "Filter: raw qlvm query or @savedName")
+	ticketsUntouchedCmd.Flags().StringVar(&untouchedType, "type", "", "Filter by ticket type (default: profile LType)")
+	ticketsUntouchedCmd.Flags().StringVar(&untouchedContract, "contract", "", "Filter by contract ID")
+	ticketsUntouchedCmd.Flags().BoolVar(&untouchedAll, "all", false, "Include closed tickets")
+	ticketsUntouchedCmd.Flags().BoolVarP(&forceTickets, "force-tickets", "f", false, "Refresh tickets response")
+
+	ticketsUntouchedCmd.RegisterFlagCompletionFunc("filter", filterTicketsNameCompletionFunc)
+	ticketsUntouchedCmd.RegisterFlagCompletionFunc("contract", contracsCompletion)
+
+	ticketsCmd.AddCommand(ticketsUntouchedCmd)
+}
+
+var ticketsUntouchedCmd = &cobra.Command{
+	Use:   "untouched",
+	Short: "List open tickets with no time logged recently",
+	Long: `Lists the tickets of your dashboard report that have no time logged by
+you in the last --days days, including those never worked on. Closed
+tickets (Encerrado, Fechado, Concluído, Cancelado, Resolvido...) are left
+out unless --all is given.
+
+Logged time comes from the cached timesheets of every month fetched so far
+(by 'list', 'cal', 'report' and friends); months never fetched are not
+counted. The report is read from the tickets cache; -f refreshes it.
+
+Examples:
+  intracli tickets untouched
+  intracli tickets untouched --days 14
+  intracli tickets untouched --filter "priority <= 2"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if untouchedDays < 0 {
+			log.Fatal(i18n.T("--days must not be negative"))
+		}
+
+		tickets, err := loadReport(newReportOptions(untouchedType, untouchedContract), forceTickets)
+		if err != nil {
+			log.Fatal(i18n.T("Error getting tickets: %v", err))
+		}
+		tickets, err = utils.ApplyTickets(resolveFilter(untouchedFilter, appConfig.SavedTicketFilters), tickets)
+		if err != nil {
+			log.Fatal(i18n.T("invalid --filter: %v", err))
+		}
+
+		efforts := loadTicketEfforts()
+		// Timesheet dates are calendar days at UTC midnight.
+		now := time.Now()
+		cutoff := time.Date(now.Year(), now.Month(), now.Day()-untouchedDays, 0, 0, 0, 0, time.UTC)
+
+		var open, untouched []mantis.TicketResponse
+		for _, t := range tickets {
+			if !untouchedAll && utils.IsClosedStatus(t.Status) {
+				continue
+			}
+			open = append(open, t)
+			if e, ok := efforts[t.TicketNumber]; ok && !e.LastDate.Before(cutoff) {
+				continue
+			}
+			untouched = append(untouched, t)
+		}
+		if len(untouched) == 0 {
+			fmt.Println(i18n.T("Every ticket has time logged in the last %d days.", untouchedDays))
+			return
+		}
+
+		// Least recently worked first; never-worked tickets by age.
+		sort.SliceStable(untouched, func(i, j int) bool {
+			a, b := efforts[untouched[i].TicketNumber], efforts[untouched[j].TicketNumber]
+			if !a.LastDate.Equal(b.LastDate) {
+				return a.LastDate.Before(b.LastDate)
+			}
+			return untouched[i].TicketCreated < untouched[j].TicketCreated
+		})
+
+		table := tablewriter.NewTable(os.Stdout,
+			tablewriter.WithConfig(tablewriter.Config{
+				Row: tw.CellConfig{
+					Formatting: tw.CellFormatting{AutoWrap: tw.WrapNone},
+					Alignment:  tw.CellAlignment{Global: tw.AlignLeft},
+				},
+			}),
+		)
+		table.Header(i18n.T("Ticket Number"), i18n.T("Status"), i18n.T("Priority"), "SLA",
+			i18n.T("Logged"), i18n.T("Last Logged"), i18n.T("Description"))
+		for _, t := range untouched {
+			e := efforts[t.TicketNumber]
+			table.Append(
+				t.TicketNumber,
+				t.Status,
+				colorPriority(t.Priority),
+				colorSLA(t.PercSLA),
+				formatEffort(e),
+				formatLastLogged(e),
+				truncate(t.Description, 50),
+			)
+		}
+		if err := table.Render(); err != nil {
+			log.Fatal(err)
+		}
+		utils.MutedStyle.Println(i18n.T("%d of %d ticket(s) untouched for %d days.", len(untouched), len(open), untouchedDays))
+	},
+}
+
+// loadTicketEfforts sums the current user's cached timesheets by ticket.
+// Errors only cost the effort columns, so they are logged and ignored.
+func loadTicketEfforts() map[string]utils.TicketEffort {
+	// The cache is keyed by currentUserID, which may come from auth when
+	// the profile has no userID.
+	timesheets, err := loadCachedTimesheets(currentUserID)
+	if err != nil {
+		log.Print(i18n.T("Warning: could not read cached timesheets: %v", err))
+		return nil
+	}
+	return utils.TicketEfforts(timesheets)
+}
+
+// loadCachedTimesheets reads every cached month of the user's timesheets.
+func loadCachedTimesheets(userID int) ([]mantis.TimesheetsResponse, error) {
+	files, err := cache.ListCacheFiles(fmt.Sprintf("timesheets_%d_", userID))
+	if err != nil {
+		return nil, err
+	}
+
+	var out []mantis.TimesheetsResponse
+	for _, name := range files {
+		timesheets, err := cache.ReadFromCache[mantis.TimesheetsResponse](name)
+		if err != nil {
+			log.Print(i18n.T("Skipping cache file %s: %v", name, err))
+			continue
+		}
+		out = append(out, timesheets...)
+	}
+	return out, nil
+}
+
+func formatEffort(e utils.TicketEffort) string {
+	if e.Entries == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%d)", i18n.Hours(e.Hours), e.Entries)
+}
+
+func formatLastLogged(e utils.TicketEffort) string {
+	if e.LastDate.IsZero() {
+		return i18n.T("never")
+	}
+	if humanDates {
+		return humanizeTime(e.LastDate)
+	}
+	return e.LastDate.Format("2006-01-02")
+}
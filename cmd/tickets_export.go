@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/spf13/cobra"
+)
+
+var exportFormatValues = []string{"md", "html", "json"}
+
+var (
+	exportTicket      string
+	exportFormat      string
+	exportOutput      string
+	exportAttachments bool
+)
+
+func init() {
+	ticketsExportCmd.Flags().StringVarP(&exportTicket, "ticket", "t", "", "Ticket to export")
+	ticketsExportCmd.Flags().StringVar(&exportFormat, "format", "md", "Output format: md|html|json")
+	ticketsExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default stdout)")
+	ticketsExportCmd.Flags().BoolVarP(&exportAttachments, "attachments", "a", false, "Download attachments into a sibling folder and link them")
+
+	ticketsExportCmd.RegisterFlagCompletionFunc("ticket", ticketCompletionFunc)
+	ticketsExportCmd.RegisterFlagCompletionFunc(
+		"format",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return exportFormatValues, cobra.ShellCompDirectiveNoFileComp
+		},
+	)
+
+	ticketsCmd.AddCommand(ticketsExportCmd)
+}
+
+// ticketExport is a ticket thread as written by 'tickets export'.
+type ticketExport struct {
+	Ticket        string             `json:"ticket"`
+	Status        string             `json:"status"`
+	Priority      string             `json:"priority"`
+	ProcessType   string             `json:"processType"`
+	Category      string             `json:"category"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	ChangedAt     time.Time          `json:"changedAt"`
+	CreatedBy     string             `json:"createdBy"`
+	Processor     string             `json:"processor"`
+	ApprovedHours string             `json:"approvedHours,omitempty"`
+	ConsumedHours string             `json:"consumedHours,omitempty"`
+	Description   string             `json:"description"`
+	Texts         []exportText       `json:"texts"`
+	Attachments   []exportAttachment `json:"attachments"`
+}
+
+type exportText struct {
+	ID     string    `json:"id"`
+	Time   time.Time `json:"time"`
+	Author string    `json:"author"`
+	Email  string    `json:"email,omitempty"`
+	// HTML is the sanitized original text, Markdown its conversion.
+	HTML     string `json:"html"`
+	Markdown string `json:"markdown"`
+}
+
+type exportAttachment struct {
+	Name      string    `json:"name"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Path is relative to the exported file; empty when not downloaded.
+	Path string `json:"path,omitempty"`
+}
+
+var ticketsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a ticket thread to Markdown, HTML or JSON",
+	Long: `Writes a ticket's metadata and its full thread, oldest entry first, for
+handing over or escalating.
+
+Texts are converted to Markdown for --format md. The HTML export keeps the
+original markup where it is safe (formatting, lists, tables, links) and drops
+the rest. The JSON export carries both.
+
+With --attachments the files are downloaded into a folder next to the output
+(<output>_files, or <ticket>_files when writing to stdout) and linked.
+
+Examples:
+  intracli tickets export -t 8000123 > 8000123.md
+  intracli tickets export -t 8000123 --format html -o escalation.html -a
+  intracli tickets export -t 8000123 --format json | jq '.texts[].author'`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if exportTicket == "" {
+			log.Fatal(i18n.T("Error: a ticket is required. Use --ticket (-t)."))
+		}
+		if !slices.Contains(exportFormatValues, exportFormat) {
+			log.Fatal(i18n.T("Invalid --format %q: expected md|html|json", exportFormat))
+		}
+
+		resp, err := fetchSupportInfo(exportTicket)
+		if err != nil {
+			log.Fatal(i18n.T("Error getting ticket %s: %v", exportTicket, err))
+		}
+		export := buildTicketExport(&resp)
+
+		if exportAttachments && len(resp.Attachments) > 0 {
+			baseDir, folder := ".", exportTicket+"_files"
+			if exportOutput != "" {
+				baseDir = filepath.Dir(exportOutput)
+				folder = strings.TrimSuffix(filepath.Base(exportOutput), filepath.Ext(exportOutput)) + "_files"
+			}
+			downloadExportAttachments(resp.Attachments, &export, baseDir, folder)
+		}
+
+		var out io.Writer = os.Stdout
+		if exportOutput != "" {
+			f, err := os.Create(exportOutput)
+			if err != nil {
+				log.Fatal(i18n.T("Failed to create %s: %v", exportOutput, err))
+			}
+			defer f.Close()
+			out = f
+		}
+
+		switch exportFormat {
+		case "md":
+			err = writeTicketMarkdown(out, export)
+		case "html":
+			err = ticketHTMLTemplate.Execute(out, export)
+		case "json":
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(export)
+		}
+		if err != nil {
+			log.Fatal(i18n.T("Failed to write export: %v", err))
+		}
+		if exportOutput != "" {
+			fmt.Fprintln(os.Stderr, i18n.T("Saved: %s", exportOutput))
+		}
+	},
+}
+
+func buildTicketExport(t *mantis.SupportInfoResponse) ticketExport {
+	export := ticketExport{
+		Ticket:        t.ObjectID,
+		Status:        t.UserStatusDescription,
+		Priority:      t.Priority,
+		ProcessType:   t.ProcessType,
+		Category:      t.CategoryID,
+		CreatedAt:     t.CreatedAt,
+		ChangedAt:     t.ChangedAt,
+		CreatedBy:     formatPerson(t.CreatedBy.Name, t.CreatedBy.Email),
+		Processor:     formatPerson(t.ProcessorDetail.Name, t.ProcessorDetail.Email),
+		ApprovedHours: t.TotHrAprovadaPC,
+		ConsumedHours: t.TotHrPC,
+		Description:   t.Description,
+	}
+	if t.ProcessTypeDescription != "" {
+		export.ProcessType = t.ProcessTypeDescription
+	}
+	if export.Ticket == "" {
+		export.Ticket = exportTicket
+	}
+
+	for _, tx := range sortedTexts(t.Texts) {
+		et := exportText{
+			ID:       tx.TdID,
+			Time:     tx.TDFCreatedAt,
+			Author:   tx.TDFUser,
+			HTML:     utils.SanitizeHTML(tx.Text),
+			Markdown: utils.HTMLToMarkdown(tx.Text),
+		}
+		if tx.UserInformation != nil && tx.UserInformation.Name != "" {
+			et.Author = tx.UserInformation.Name
+			et.Email = tx.UserInformation.Email
+		}
+		export.Texts = append(export.Texts, et)
+	}
+
+	for _, a := range t.Attachments {
+		export.Attachments = append(export.Attachments, exportAttachment{
+			Name:      a.FileName,
+			Author:    a.CreatedBy.Name,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+	return export
+}
+
+// downloadExportAttachments saves the attachments into baseDir/folder and
+// records their paths relative to baseDir. Failures are reported and the
+// attachment is left unlinked.
+func downloadExportAttachments(atts []mantis.Attachment, export *ticketExport, baseDir, folder string) {
+	dir := filepath.Join(baseDir, folder)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Print(i18n.T("Warning: failed to create %s: %v", dir, err))
+		return
+	}
+
+	for i, att := range atts {
+		fmt.Fprintln(os.Stderr, i18n.T("Downloading attachment: %s", att.FileName))
+		file, err := mantisClient.Dashboard.GetSupportFile(mantisCtx, att)
+		if err != nil {
+			log.Print(i18n.T("Warning: failed to download %s: %v", att.FileName, err))
+			continue
+		}
+
+		saved, err := saveAttachment(dir, att.FileName, file.FileContent)
+		if err != nil {
+			log.Print(i18n.T("Warning: failed to save %s: %v", att.FileName, err))
+			continue
+		}
+		rel, err := filepath.Rel(baseDir, saved.Path)
+		if err != nil {
+			rel = saved.Path
+		}
+		export.Attachments[i].Path = filepath.ToSlash(rel)
+	}
+}
+
+func writeTicketMarkdown(w io.Writer, e ticketExport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", i18n.T("Ticket %s", e.Ticket))
+	fmt.Fprintf(&b, "| %s | %s |\n| --- | --- |\n", i18n.T("Field"), i18n.T("Value"))
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "| %s | %s |\n", i18n.T(name), strings.ReplaceAll(value, "|", `\|`))
+		}
+	}
+	field("Status", e.Status)
+	field("Priority", e.Priority)
+	field("Process type", e.ProcessType)
+	field("Category", e.Category)
+	field("Created", formatExportTime(e.CreatedAt))
+	field("Changed", formatExportTime(e.ChangedAt))
+	field("Created by", e.CreatedBy)
+	field("Processor", e.Processor)
+	if e.ApprovedHours != "" {
+		field("Project hours", i18n.T("%s consumed of %s approved", e.ConsumedHours, e.ApprovedHours))
+	}
+
+	fmt.Fprintf(&b, "\n## %s\n\n", i18n.T("Description"))
+	b.WriteString(utils.HTMLToMarkdown(e.Description))
+	fmt.Fprintf(&b, "\n\n## %s\n", i18n.T("Thread"))
+
+	for _, tx := range e.Texts {
+		fmt.Fprintf(&b, "\n### %s · %s", formatExportTime(tx.Time), tx.Author)
+		if tx.ID != "" {
+			fmt.Fprintf(&b, " (%s)", tx.ID)
+		}
+		b.WriteString("\n\n" + tx.Markdown + "\n")
+	}
+
+	if len(e.Attachments) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", i18n.T("Attachments"))
+		for _, a := range e.Attachments {
+			name := a.Name
+			if a.Path != "" {
+				name = fmt.Sprintf("[%s](<%s>)", a.Name, a.Path)
+			}
+			fmt.Fprintf(&b, "- %s%s\n", name, attachmentByline(a))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var ticketHTMLTemplate = template.Must(template.New("ticket").Funcs(template.FuncMap{
+	"t":       i18n.T,
+	"time":    formatExportTime,
+	"byline":  attachmentByline,
+	"safe":    func(s string) template.HTML { return template.HTML(utils.SanitizeHTML(s)) },
+	"trusted": func(s string) template.HTML { return template.HTML(s) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{t "Ticket %s" .Ticket}}</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: 2em auto; line-height: 1.5; }
+table.meta td { padding: 0.2em 1em 0.2em 0; vertical-align: top; }
+table.meta td:first-child { color: #666; }
+.text { border-left: 3px solid #ccc; padding: 0 1em; margin: 1.5em 0; }
+.text header { color: #666; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>{{t "Ticket %s" .Ticket}}</h1>
+<table class="meta">
+<tr><td>{{t "Status"}}</td><td>{{.Status}}</td></tr>
+<tr><td>{{t "Priority"}}</td><td>{{.Priority}}</td></tr>
+<tr><td>{{t "Process type"}}</td><td>{{.ProcessType}}</td></tr>
+<tr><td>{{t "Category"}}</td><td>{{.Category}}</td></tr>
+<tr><td>{{t "Created"}}</td><td>{{time .CreatedAt}}</td></tr>
+<tr><td>{{t "Changed"}}</td><td>{{time .ChangedAt}}</td></tr>
+{{- if .CreatedBy}}
+<tr><td>{{t "Created by"}}</td><td>{{.CreatedBy}}</td></tr>
+{{- end}}
+{{- if .Processor}}
+<tr><td>{{t "Processor"}}</td><td>{{.Processor}}</td></tr>
+{{- end}}
+{{- if .ApprovedHours}}
+<tr><td>{{t "Project hours"}}</td><td>{{t "%s consumed of %s approved" .ConsumedHours .ApprovedHours}}</td></tr>
+{{- end}}
+</table>
+<h2>{{t "Description"}}</h2>
+<div>{{safe .Description}}</div>
+<h2>{{t "Thread"}}</h2>
+{{- range .Texts}}
+<section class="text">
+<header>{{time .Time}} · <strong>{{.Author}}</strong>{{if .Email}} &lt;{{.Email}}&gt;{{end}}{{if .ID}} ({{.ID}}){{end}}</header>
+<div>{{trusted .HTML}}</div>
+</section>
+{{- end}}
+{{- if .Attachments}}
+<h2>{{t "Attachments"}}</h2>
+<ul>
+{{- range .Attachments}}
+<li>{{if .Path}}<a href="{{.Path}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}{{byline .}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`))
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func attachmentByline(a exportAttachment) string {
+	var s string
+	if a.Author != "" {
+		s += i18n.T(", by %s", a.Author)
+	}
+	if !a.CreatedAt.IsZero() {
+		s += i18n.T(" on %s", formatExportTime(a.CreatedAt))
+	}
+	return s
+}
+
+func formatPerson(name, email string) string {
+	switch {
+	case name == "":
+		return email
+	case email == "":
+		return name
+	default:
+		return fmt.Sprintf("%s <%s>", name, email)
+	}
+}
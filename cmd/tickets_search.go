@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/search"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/spf13/cobra"
+)
+
+const searchSnippetWidth = 100
+
+var (
+	searchStatus   string
+	searchContract string
+	searchFrom     string
+	searchTo       string
+	searchLimit    int
+	searchRebuild  bool
+)
+
+func init() {
+	ticketsSearchCmd.Flags().StringVar(&searchStatus, "status", "", "Only tickets whose status contains this text")
+	ticketsSearchCmd.Flags().StringVar(&searchContract, "contract", "", "Only tickets whose contract ID or title contains this text")
+	ticketsSearchCmd.Flags().StringVar(&searchFrom, "from", "", "Only tickets created on or after this date (YYYY-MM-DD or token)")
+	ticketsSearchCmd.Flags().StringVar(&searchTo, "to", "", "Only tickets created on or before this date (YYYY-MM-DD or token)")
+	ticketsSearchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 20, "Maximum number of results (0 for all)")
+	ticketsSearchCmd.Flags().BoolVar(&searchRebuild, "rebuild", false, "Rebuild the index from scratch")
+
+	ticketsSearchCmd.RegisterFlagCompletionFunc("contract", contracsCompletion)
+
+	ticketsCmd.AddCommand(ticketsSearchCmd)
+}
+
+var ticketsSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Full-text search across cached tickets and their texts",
+	Long: `Searches the tickets known locally: the descriptions of every cached
+ticket report and the texts of every ticket inspected with 'tickets -t'
+(or exported, watched or budgeted). Works offline.
+
+Every word of the query must match; the last one also matches as a prefix.
+Accents and case are ignored. Results are ranked by relevance, with
+descriptions weighing more than texts.
+
+The index is updated incrementally from the cache on every search.
+
+Examples:
+  intracli tickets search "timeout sefaz"
+  intracli tickets search rejeição --status encerrado --from 2026-01-01
+  intracli tickets search nfe --contract 4100123 -n 5`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		var filter search.Filter
+		filter.Status = searchStatus
+		filter.Contract = searchContract
+		if searchFrom != "" {
+			from, err := parseRangeDate(searchFrom)
+			if err != nil {
+				log.Fatal(err)
+			}
+			filter.From = from
+		}
+		if searchTo != "" {
+			to, err := parseRangeDate(searchTo)
+			if err != nil {
+				log.Fatal(err)
+			}
+			filter.To = to.AddDate(0, 0, 1)
+		}
+
+		idx, updated, err := updateSearchIndex(searchRebuild)
+		if err != nil {
+			log.Fatal(i18n.T("Error updating search index: %v", err))
+		}
+		if updated > 0 {
+			utils.MutedStyle.Println(i18n.T("Indexed %d new or changed ticket(s).", updated))
+		}
+		if idx.Len() == 0 {
+			fmt.Println(i18n.T("No tickets cached yet. Run 'intracli tickets' or 'intracli tickets -t <ticket>' first."))
+			return
+		}
+
+		query := strings.Join(args, " ")
+		results := idx.Search(query, filter)
+		if len(results) == 0 {
+			fmt.Println(i18n.T("No tickets match %q.", query))
+			return
+		}
+
+		shown := results
+		if searchLimit > 0 && len(shown) > searchLimit {
+			shown = shown[:searchLimit]
+		}
+		for _, r := range shown {
+			renderSearchResult(r)
+		}
+		if len(shown) < len(results) {
+			utils.MutedStyle.Println(i18n.T("%d more result(s); use --limit to see them.", len(results)-len(shown)))
+		}
+	},
+}
+
+func renderSearchResult(r search.Result) {
+	d := r.Doc
+	highlight := func(s string) string { return utils.MatchStyle.Sprint(s) }
+
+	utils.TitleStyle.Print(d.Ticket)
+	fmt.Printf("  %s", d.Status)
+	if !d.Created.IsZero() {
+		utils.MutedStyle.Printf("  %s", d.Created.Format("2006-01-02"))
+	}
+	if d.Contract != "" {
+		utils.MutedStyle.Printf("  %s", truncate(d.Contract, 40))
+	}
+	fmt.Println()
+
+	desc := search.Snippet(d.Description, r.Terms, searchSnippetWidth, highlight)
+	if desc == "" {
+		desc = truncate(d.Description, searchSnippetWidth)
+	}
+	fmt.Printf("  %s\n", desc)
+
+	for _, text := range d.Texts {
+		if s := search.Snippet(text, r.Terms, searchSnippetWidth, highlight); s != "" {
+			fmt.Printf("  %s %s\n", utils.MutedStyle.Sprint("»"), s)
+			break
+		}
+	}
+	fmt.Println()
+}
+
+// updateSearchIndex loads the persisted index and upserts every ticket found
+// in the cache: report entries from the tickets_ files and details from the
+// support_ files. Unchanged tickets are skipped by fingerprint.
+func updateSearchIndex(rebuild bool) (*search.Index, int, error) {
+	var idx *search.Index
+	docs, docsErr := cache.ReadFromCache[search.Doc](cache.SearchDocsCacheFileName)
+	entries, entriesErr := cache.ReadFromCache[search.Entry](cache.SearchIndexCacheFileName)
+	if rebuild || docsErr != nil || entriesErr != nil {
+		idx = search.NewIndex(nil, nil)
+	} else {
+		idx = search.NewIndex(docs, entries)
+	}
+
+	tickets, err := loadAndMergeCachedTickets()
+	if err != nil {
+		return nil, 0, err
+	}
+	byNumber := make(map[string]search.Doc, len(tickets))
+	for _, t := range tickets {
+		byNumber[t.TicketNumber] = search.Doc{
+			Ticket:      t.TicketNumber,
+			Status:      t.Status,
+			ContractID:  t.IDContrato,
+			Contract:    t.TituloContrato,
+			Created:     parseTime(t.TicketCreated),
+			Description: t.Description,
+		}
+	}
+
+	files, err := cache.ListCacheFiles("support_")
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, name := range files {
+		infos, err := cache.ReadFromCache[mantis.SupportInfoResponse](name)
+		if err != nil || len(infos) == 0 {
+			continue
+		}
+		info := infos[0]
+		number := strings.TrimSuffix(strings.TrimPrefix(name, "support_"), ".json")
+
+		d, ok := byNumber[number]
+		if !ok {
+			d = search.Doc{Ticket: number, Created: info.CreatedAt}
+		}
+		if info.UserStatusDescription != "" {
+			d.Status = info.UserStatusDescription
+		}
+		if d.Description == "" {
+			d.Description = stripHTML(info.Description)
+		}
+		d.Texts = nil
+		for _, tx := range sortedTexts(info.Texts) {
+			d.Texts = append(d.Texts, stripHTML(tx.Text))
+		}
+		byNumber[number] = d
+	}
+
+	updated := 0
+	for _, d := range byNumber {
+		if idx.Upsert(d) {
+			updated++
+		}
+	}
+
+	if updated > 0 {
+		if err := cache.WriteToCache(cache.SearchDocsCacheFileName, idx.Docs()); err != nil {
+			return nil, 0, err
+		}
+		if err := cache.WriteToCache(cache.SearchIndexCacheFileName, idx.Entries()); err != nil {
+			return nil, 0, err
+		}
+	}
+	return idx, updated, nil
+}
@@ -0,0 +1,664 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/tui"
+	"github.com/Salvadego/IntraCLI/types"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
+	"github.com/spf13/cobra"
+)
+
+// ticketsTUIKeys is shown in the help of the command and of the screen.
+const ticketsTUIKeys = `  ↑↓ j k        move             PgUp PgDn g G   page / first / last
+  /             filter as you type (Esc clears)
+  s  o          cycle sort key / toggle order
+  Enter →       open details     Tab             switch list/details
+  Esc ←         close details    ?               help
+  a             download attachments into --dir
+  t             log time on the ticket (as 'appoint -t')
+  r             refresh from Mantis
+  q Ctrl-C      quit
+`
+
+// splitWidth is the terminal width from which the detail pane is shown
+// beside the list instead of replacing it.
+const splitWidth = 140
+
+var (
+	tuiTicketType string
+	tuiContract   string
+	tuiFilter     string
+)
+
+func init() {
+	ticketsTUICmd.Flags().StringVar(&tuiTicketType, "type", "", "Filter by ticket type (default: profile LType)")
+	ticketsTUICmd.Flags().StringVar(&tuiContract, "contract", "", "Filter by contract ID")
+	ticketsTUICmd.Flags().StringVarP(&tuiFilter, "filter", "F", "", "Filter: raw qlvm query or @savedName")
+	ticketsTUICmd.Flags().StringVar(&attachmentDir, "dir", ".", "Directory to download attachments into")
+	ticketsTUICmd.Flags().BoolVarP(&forceTickets, "force-tickets", "f", false, "Refresh tickets response")
+
+	ticketsTUICmd.RegisterFlagCompletionFunc("contract", contracsCompletion)
+	ticketsTUICmd.RegisterFlagCompletionFunc("filter", filterTicketsNameCompletionFunc)
+
+	ticketsCmd.AddCommand(ticketsTUICmd)
+}
+
+var ticketsTUICmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse tickets in a full-screen interactive view",
+	Long: `Opens the dashboard report as a keyboard-driven list grouped by status,
+like 'intracli tickets'. Enter shows the ticket details in a pane beside the
+list (or full screen on narrow terminals).
+
+Keys:
+` + ticketsTUIKeys + `
+Examples:
+  intracli tickets tui
+  intracli tickets tui --filter @urgent --dir ~/Downloads`,
+	Run: func(cmd *cobra.Command, args []string) {
+		b := &ticketBrowser{
+			opts:    newReportOptions(tuiTicketType, tuiContract),
+			query:   resolveFilter(tuiFilter, appConfig.SavedTicketFilters),
+			order:   "desc",
+			details: map[string][]string{},
+			infos:   map[string]*mantis.SupportInfoResponse{},
+		}
+		if err := b.load(forceTickets); err != nil {
+			log.Fatal(i18n.T("Error getting tickets: %v", err))
+		}
+		b.rebuild()
+
+		screen, err := tui.Open()
+		if err != nil {
+			log.Fatal(err)
+		}
+		b.screen = screen
+		err = b.run()
+		screen.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// ticketRow is a line of the list: a status header or a ticket.
+type ticketRow struct {
+	header string
+	count  int
+	ticket mantis.TicketResponse
+}
+
+type ticketBrowser struct {
+	screen  *tui.Screen
+	opts    *mantis.GetReportOptions
+	query   string
+	tickets []mantis.TicketResponse
+	efforts map[string]utils.TicketEffort
+
+	rows           []ticketRow
+	shown          int
+	cursor, offset int
+	search         tui.Input
+	searching      bool
+	sortKey        int
+	order          string
+
+	// infos are the fetched tickets, details their rendered lines.
+	infos        map[string]*mantis.SupportInfoResponse
+	details      map[string][]string
+	detailOpen   bool
+	focusDetail  bool
+	detailScroll int
+
+	form    *tui.Form
+	help    bool
+	message string
+}
+
+func (b *ticketBrowser) load(force bool) error {
+	tickets, err := loadReport(b.opts, force)
+	if err != nil {
+		return err
+	}
+	if b.tickets, err = utils.ApplyTickets(b.query, tickets); err != nil {
+		return i18n.Errorf("invalid --filter: %w", err)
+	}
+	b.efforts = loadTicketEfforts()
+	return nil
+}
+
+// rebuild applies the search and sort, regroups by status and keeps the
+// selected ticket selected when it is still shown.
+func (b *ticketBrowser) rebuild() {
+	selected, _ := b.selected()
+
+	needle := strings.ToLower(b.search.Value)
+	var shown []mantis.TicketResponse
+	for _, t := range b.tickets {
+		if needle == "" || strings.Contains(strings.ToLower(strings.Join([]string{
+			t.TicketNumber, t.Status, t.Priority, t.Description, t.IDContrato, t.TituloContrato,
+		}, "\x00")), needle) {
+			shown = append(shown, t)
+		}
+	}
+	sortTickets(shown, SortBy(sortByValues[b.sortKey]), b.order)
+
+	groups := map[string][]mantis.TicketResponse{}
+	for _, t := range shown {
+		groups[t.Status] = append(groups[t.Status], t)
+	}
+	statuses := make([]string, 0, len(groups))
+	for s := range groups {
+		statuses = append(statuses, s)
+	}
+	sort.Strings(statuses)
+
+	b.rows = b.rows[:0]
+	for _, s := range statuses {
+		b.rows = append(b.rows, ticketRow{header: s, count: len(groups[s])})
+		for _, t := range groups[s] {
+			b.rows = append(b.rows, ticketRow{ticket: t})
+		}
+	}
+	b.shown = len(shown)
+
+	b.cursor = 0
+	for i, r := range b.rows {
+		if r.header == "" && r.ticket.TicketNumber == selected.TicketNumber {
+			b.cursor = i
+			break
+		}
+	}
+	b.move(0)
+}
+
+func (b *ticketBrowser) selected() (mantis.TicketResponse, bool) {
+	if b.cursor < len(b.rows) && b.rows[b.cursor].header == "" {
+		return b.rows[b.cursor].ticket, true
+	}
+	return mantis.TicketResponse{}, false
+}
+
+// move steps the cursor by delta rows, skipping status headers.
+func (b *ticketBrowser) move(delta int) {
+	if len(b.rows) == 0 {
+		return
+	}
+	prev := b.cursor
+	b.cursor = max(0, min(len(b.rows)-1, b.cursor+delta))
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	for b.rows[b.cursor].header != "" {
+		next := b.cursor + step
+		if next < 0 || next >= len(b.rows) {
+			step = -step
+			next = b.cursor + step
+			if next < 0 || next >= len(b.rows) {
+				return
+			}
+		}
+		b.cursor = next
+	}
+	if b.cursor != prev {
+		b.detailScroll = 0
+	}
+}
+
+func (b *ticketBrowser) run() error {
+	for {
+		if err := b.screen.Draw(b.view()); err != nil {
+			return err
+		}
+		k, err := b.screen.ReadKey()
+		if err != nil {
+			return err
+		}
+		quit, err := b.handle(k)
+		if err != nil || quit {
+			return err
+		}
+	}
+}
+
+func (b *ticketBrowser) handle(k tui.Key) (bool, error) {
+	if k.IsCtrl('c') {
+		return true, nil
+	}
+	b.message = ""
+	_, h := b.screen.Size()
+	page := max(1, h-3)
+
+	switch {
+	case b.form != nil:
+		return false, b.handleForm(k)
+	case b.help:
+		b.help = false
+		return false, nil
+	case b.searching:
+		switch k.Code {
+		case tui.KeyEnter:
+			b.searching = false
+		case tui.KeyEsc:
+			b.searching = false
+			b.search.Value = ""
+			b.rebuild()
+		case tui.KeyUp, tui.KeyDown:
+			b.searching = false
+			return b.handle(k)
+		default:
+			if b.search.Handle(k) {
+				b.rebuild()
+			}
+		}
+		return false, nil
+	case b.focusDetail:
+		switch {
+		case k.Code == tui.KeyDown || k.Is('j'):
+			b.detailScroll++
+		case k.Code == tui.KeyUp || k.Is('k'):
+			b.detailScroll--
+		case k.Code == tui.KeyPgDn || k.Is(' '):
+			b.detailScroll += page
+		case k.Code == tui.KeyPgUp:
+			b.detailScroll -= page
+		case k.Code == tui.KeyHome || k.Is('g'):
+			b.detailScroll = 0
+		case k.Code == tui.KeyEnd || k.Is('G'):
+			b.detailScroll = 1 << 30
+		case k.Code == tui.KeyTab:
+			if w, _ := b.screen.Size(); w >= splitWidth {
+				b.focusDetail = false
+			}
+		case k.Code == tui.KeyEsc || k.Code == tui.KeyLeft || k.Is('h'):
+			b.detailOpen, b.focusDetail = false, false
+		default:
+			return b.handleAction(k)
+		}
+		b.detailScroll = max(0, b.detailScroll)
+		return false, nil
+	}
+
+	switch {
+	case k.Code == tui.KeyDown || k.Is('j'):
+		b.move(1)
+	case k.Code == tui.KeyUp || k.Is('k'):
+		b.move(-1)
+	case k.Code == tui.KeyPgDn:
+		b.move(page)
+	case k.Code == tui.KeyPgUp:
+		b.move(-page)
+	case k.Code == tui.KeyHome || k.Is('g'):
+		b.cursor = 0
+		b.move(0)
+	case k.Code == tui.KeyEnd || k.Is('G'):
+		b.cursor = len(b.rows) - 1
+		b.move(0)
+	case k.Code == tui.KeyEnter || k.Code == tui.KeyRight || k.Is('l'):
+		b.openDetail()
+	case k.Code == tui.KeyTab:
+		if b.detailOpen {
+			b.focusDetail = true
+		}
+	case k.Code == tui.KeyEsc || k.Code == tui.KeyLeft:
+		if b.detailOpen {
+			b.detailOpen = false
+		} else if b.search.Value != "" {
+			b.search.Value = ""
+			b.rebuild()
+		}
+	case k.Is('/'):
+		b.searching = true
+	case k.Is('s'):
+		b.sortKey = (b.sortKey + 1) % len(sortByValues)
+		b.rebuild()
+	case k.Is('o'):
+		if b.order == "desc" {
+			b.order = "asc"
+		} else {
+			b.order = "desc"
+		}
+		b.rebuild()
+	default:
+		return b.handleAction(k)
+	}
+	return false, nil
+}
+
+// handleAction runs the keys available from both the list and the details.
+func (b *ticketBrowser) handleAction(k tui.Key) (bool, error) {
+	switch {
+	case k.Is('q'):
+		return true, nil
+	case k.Is('?'):
+		b.help = true
+	case k.Is('r'):
+		b.status(i18n.T("Refreshing…"))
+		if err := b.load(true); err != nil {
+			b.message = err.Error()
+			return false, nil
+		}
+		clear(b.infos)
+		clear(b.details)
+		b.rebuild()
+		b.message = i18n.T("Refreshed %d ticket(s).", len(b.tickets))
+	case k.Is('a'):
+		return false, b.downloadSelected()
+	case k.Is('t'):
+		b.openLogForm()
+	}
+	return false, nil
+}
+
+// status draws the screen with a message before a slow operation.
+func (b *ticketBrowser) status(msg string) {
+	b.message = msg
+	b.screen.Draw(b.view())
+}
+
+// info returns the details of t, fetching them on first use.
+func (b *ticketBrowser) info(t mantis.TicketResponse) (*mantis.SupportInfoResponse, error) {
+	if info, ok := b.infos[t.TicketNumber]; ok {
+		return info, nil
+	}
+	b.status(i18n.T("Loading ticket %s…", t.TicketNumber))
+	resp, err := fetchSupportInfo(t.TicketNumber)
+	if err != nil {
+		return nil, err
+	}
+	b.infos[t.TicketNumber] = &resp
+
+	var buf bytes.Buffer
+	renderTicket(&buf, &resp, b.efforts)
+	b.details[t.TicketNumber] = strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	b.message = ""
+	return &resp, nil
+}
+
+func (b *ticketBrowser) openDetail() {
+	t, ok := b.selected()
+	if !ok {
+		return
+	}
+	if _, err := b.info(t); err != nil {
+		b.message = i18n.T("Error loading %s: %v", t.TicketNumber, err)
+		return
+	}
+	b.detailOpen, b.focusDetail = true, true
+	b.detailScroll = 0
+}
+
+func (b *ticketBrowser) downloadSelected() error {
+	t, ok := b.selected()
+	if !ok {
+		return nil
+	}
+	info, err := b.info(t)
+	if err != nil {
+		b.message = i18n.T("Error loading %s: %v", t.TicketNumber, err)
+		return nil
+	}
+	if len(info.Attachments) == 0 {
+		b.message = i18n.T("Ticket %s has no attachments.", t.TicketNumber)
+		return nil
+	}
+	return b.screen.Suspend(func() {
+		downloadAttachments(info.Attachments, attachmentDir, false)
+	})
+}
+
+func (b *ticketBrowser) openLogForm() {
+	t, ok := b.selected()
+	if !ok {
+		return
+	}
+	profile, err := getCurrentProfile(appConfig)
+	if err != nil {
+		b.message = err.Error()
+		return
+	}
+	b.form = newAppointForm(i18n.T("Log time on ticket %s", t.TicketNumber), profile, t.TicketNumber)
+}
+
+func (b *ticketBrowser) handleForm(k tui.Key) error {
+	profile, err := getCurrentProfile(appConfig)
+	if err != nil {
+		b.form.Message = err.Error()
+		return nil
+	}
+	var res tui.FormResult
+	updateAppointForm(b.form, profile, func() { res = b.form.Handle(k) })
+	switch res {
+	case tui.FormCancelled:
+		b.form = nil
+	case tui.FormSubmitted:
+		entry, err := appointFormEntry(b.form, profile)
+		if err != nil {
+			b.form.Message = utils.ErrorStyle.Sprint(err)
+			return nil
+		}
+		b.form = nil
+		return b.screen.Suspend(func() {
+			fmt.Println(i18n.T("Attempting to create appointment:\n%+v", entry))
+			appoint(mantisClient, currentUserID, entry, mantisCtx)
+		})
+	}
+	return nil
+}
+
+func (b *ticketBrowser) view() []string {
+	w, h := b.screen.Size()
+	bodyH := max(0, h-2)
+
+	title := " IntraCLI tickets  " + i18n.T("%d of %d  sort: %s %s",
+		b.shown, len(b.tickets), sortByValues[b.sortKey], b.order)
+	if b.searching || b.search.Value != "" {
+		title += "  /" + b.search.Value
+		if b.searching {
+			title += "_"
+		}
+	}
+	lines := []string{tui.Reverse(title, w)}
+
+	var body []string
+	switch {
+	case b.form != nil:
+		body = b.form.Lines(w)
+	case b.help:
+		body = strings.Split(i18n.T("Keys:")+"\n"+ticketsTUIKeys, "\n")
+	case b.detailOpen && w >= splitWidth:
+		listW := w * 2 / 5
+		left := b.listLines(listW, bodyH)
+		right := b.detailLines(w-listW-3, bodyH)
+		for i := range bodyH {
+			body = append(body, tui.Pad(left[i], listW)+utils.MutedStyle.Sprint(" │ ")+right[i])
+		}
+	case b.detailOpen:
+		body = b.detailLines(w, bodyH)
+	default:
+		body = b.listLines(w, bodyH)
+	}
+	for len(body) < bodyH {
+		body = append(body, "")
+	}
+	lines = append(lines, body[:bodyH]...)
+
+	footer := b.message
+	if footer == "" {
+		footer = i18n.T("↑↓ move  / filter  s sort  o order  ⏎ details  a attachments  t log time  r refresh  ? help  q quit")
+		footer = utils.MutedStyle.Sprint(footer)
+	}
+	return append(lines, " "+footer)
+}
+
+func (b *ticketBrowser) listLines(width, height int) []string {
+	out := make([]string, 0, height)
+	if len(b.rows) == 0 {
+		out = append(out, "  "+i18n.T("No tickets match."))
+	}
+	b.offset = tui.Scroll(b.offset, b.cursor, height, len(b.rows))
+	for i := b.offset; i < len(b.rows) && len(out) < height; i++ {
+		r := b.rows[i]
+		if r.header != "" {
+			out = append(out, utils.SectionStyle.Sprintf("■ %s ", r.header)+utils.MutedStyle.Sprintf("(%d)", r.count))
+			continue
+		}
+
+		t := r.ticket
+		logged := ""
+		if e, ok := b.efforts[t.TicketNumber]; ok {
+			logged = i18n.Float(e.Hours, 1) + "h"
+		}
+		line := fmt.Sprintf("  %-10s %s %s %s %s",
+			t.TicketNumber,
+			tui.Pad(colorPriority(t.Priority), 14),
+			tui.Pad(colorSLA(t.PercSLA), 4),
+			tui.Pad(logged, 6),
+			strings.Join(strings.Fields(t.Description), " "),
+		)
+		if i == b.cursor {
+			if b.focusDetail {
+				line = tui.Bold(line)
+			} else {
+				line = tui.Reverse(line, width)
+			}
+		}
+		out = append(out, line)
+	}
+	for len(out) < height {
+		out = append(out, "")
+	}
+	return out
+}
+
+func (b *ticketBrowser) detailLines(width, height int) []string {
+	var lines []string
+	t, ok := b.selected()
+	raw, loaded := b.details[t.TicketNumber]
+	switch {
+	case !ok:
+	case !loaded:
+		lines = []string{"", "  " + i18n.T("Press Enter to load ticket %s.", t.TicketNumber)}
+	default:
+		for _, l := range raw {
+			lines = append(lines, strings.Split(wrap.String(wordwrap.String(l, width), width), "\n")...)
+		}
+	}
+
+	b.detailScroll = max(0, min(b.detailScroll, len(lines)-height))
+	out := lines[min(b.detailScroll, len(lines)):]
+	out = out[:min(len(out), height)]
+	for len(out) < height {
+		out = append(out, "")
+	}
+	return out
+}
+
+// newAppointForm builds the form used to log time on a ticket from the
+// TUIs: the project defaults to the first alias that needs a ticket, and
+// the other fields to that alias's defaults.
+func newAppointForm(title string, profile config.Profile, ticketNo string) *tui.Form {
+	aliases := make([]string, 0, len(profile.ProjectAliases))
+	for alias := range profile.ProjectAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	project := ""
+	for _, alias := range aliases {
+		if project == "" || (ticketNo != "" && profile.ProjectAliases[alias].NeedsTicket && !profile.ProjectAliases[project].NeedsTicket) {
+			project = alias
+		}
+	}
+
+	typeNames := make([]string, 0, len(types.TimesheetTypeLookup))
+	for name := range types.TimesheetTypeLookup {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+	typeName := normalTypeName()
+	if typeName == "" && len(typeNames) > 0 {
+		typeName = typeNames[0]
+	}
+
+	day := time.Now().Format("2006-01-02")
+	defaults := aliasFormDefaults(profile, project, day)
+	if ticketNo == "" {
+		ticketNo = defaults["Ticket"]
+	}
+	if defaults["Type"] != "" {
+		typeName = defaults["Type"]
+	}
+
+	fields := []tui.Field{
+		{Key: "Hours", Label: i18n.T("Hours"), Input: tui.Input{Value: defaults["Hours"]}},
+		{Key: "Description", Label: i18n.T("Description"), Input: tui.Input{Value: defaults["Description"]}},
+		{Key: "Ticket", Label: i18n.T("Ticket"), Input: tui.Input{Value: ticketNo}},
+		{Key: "Project", Label: i18n.T("Project"), Options: aliases, Input: tui.Input{Value: project}},
+		{Key: "Type", Label: i18n.T("Type"), Options: typeNames, Input: tui.Input{Value: typeName}},
+		{Key: "Date", Label: i18n.T("Date"), Input: tui.Input{Value: day}},
+	}
+	return &tui.Form{Title: title, Fields: fields}
+}
+
+// normalTypeName returns the name of the Normal ("N") timesheet type.
+func normalTypeName() string {
+	return types.TimesheetTypeInverseLookup["N"]
+}
+
+// aliasFormDefaults returns the values an appointment form takes from the
+// defaults of alias on day, keyed by field key.
+func aliasFormDefaults(profile config.Profile, alias, day string) map[string]string {
+	a := profile.ProjectAliases[alias]
+	typeName := a.Type
+	if typeName == "" {
+		typeName = normalTypeName()
+	}
+	return map[string]string{
+		"Hours":       a.Hours,
+		"Description": expandDescription(a.Description, day),
+		"Ticket":      a.Ticket,
+		"Type":        typeName,
+	}
+}
+
+// updateAppointForm runs change on a form built by newAppointForm. Fields
+// still holding the defaults of the previous project and date take those
+// of the new ones, so typed values are kept.
+func updateAppointForm(f *tui.Form, profile config.Profile, change func()) {
+	before := aliasFormDefaults(profile, f.Value("Project"), f.Value("Date"))
+	change()
+	after := aliasFormDefaults(profile, f.Value("Project"), f.Value("Date"))
+	if maps.Equal(before, after) {
+		return
+	}
+	for key, value := range after {
+		if f.Value(key) == before[key] {
+			f.Set(key, value)
+		}
+	}
+}
+
+// appointFormEntry validates a form built by newAppointForm.
+func appointFormEntry(f *tui.Form, profile config.Profile) (TimesheetEntry, error) {
+	if f.Value("Hours") == "" {
+		return TimesheetEntry{}, i18n.Errorf("hours are required")
+	}
+	if f.Value("Description") == "" {
+		return TimesheetEntry{}, i18n.Errorf("description is required")
+	}
+	return newTimesheetEntry(profile, f.Value("Project"), f.Value("Ticket"),
+		f.Value("Type"), f.Value("Hours"), f.Value("Date"), f.Value("Description"))
+}
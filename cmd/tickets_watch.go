@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/notify"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchInterval time.Duration
+	watchType     string
+	watchContract string
+	watchNotify   bool
+)
+
+func init() {
+	ticketsWatchCmd.Flags().DurationVar(&watchInterval, "interval", 0, "Poll again at this interval (e.g. 5m); run once when 0")
+	ticketsWatchCmd.Flags().StringVar(&watchType, "type", "", "Filter by ticket type (default: profile LType)")
+	ticketsWatchCmd.Flags().StringVar(&watchContract, "contract", "", "Filter by contract ID")
+	ticketsWatchCmd.Flags().BoolVar(&watchNotify, "notify", false, "Send notifications for SLA drops and status changes")
+
+	ticketsWatchCmd.RegisterFlagCompletionFunc("contract", contracsCompletion)
+
+	ticketsCmd.AddCommand(ticketsWatchCmd)
+}
+
+var ticketsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Show what changed in your tickets since the last look",
+	Long: `Fetches the dashboard report and compares it with the snapshot stored by
+the previous run, listing new tickets, tickets that left the report, status
+and priority changes, and tickets whose SLA fell into a worse band
+(80%, 50%, breached).
+
+For the watched tickets of the profile (see 'intracli budget --add') the
+ticket texts are compared as well, and new comments are printed.
+
+The first run only records a baseline. With --interval it keeps polling and
+prints only the changes. With --notify the configured notification sinks
+fire as well (see 'intracli daemon').
+
+Examples:
+  intracli tickets watch
+  intracli tickets watch --interval 10m --notify
+  intracli tickets watch --contract 4100123`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := getCurrentProfile(appConfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opts := newReportOptions(watchType, watchContract)
+		snapshotFile := fmt.Sprintf(cache.WatchCacheFileName, opts.Signature())
+
+		var sinks []notify.Sink
+		rules := notify.RulesFromConfig(appConfig.Notifications, profile.SUserID)
+		if watchNotify {
+			if sinks, err = notify.FromConfig(appConfig.Notifications); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		for {
+			poll, err := pollTicketChanges(opts, snapshotFile, profile.WatchedTickets)
+			switch {
+			case mantisCtx.Err() != nil:
+				return
+			case err != nil && watchInterval <= 0:
+				log.Fatal(i18n.T("Error watching tickets: %v", err))
+			case err != nil:
+				utils.ErrorStyle.Printf("%s  %v\n", time.Now().Format("15:04"), err)
+			case poll.Baseline:
+				fmt.Println(i18n.T("No previous snapshot: recorded a baseline."))
+			case len(poll.Changes) > 0:
+				if watchInterval > 0 {
+					utils.MutedStyle.Printf("── %s ──\n", time.Now().Format("2006-01-02 15:04"))
+				}
+				printTicketChanges(poll.Changes)
+			case watchInterval <= 0:
+				fmt.Println(i18n.T("No changes."))
+			}
+
+			if err == nil && !poll.Baseline && watchNotify {
+				dispatchNotifications(sinks, rules.Evaluate(poll.Prev, poll.Curr))
+			}
+
+			if watchInterval <= 0 {
+				return
+			}
+			select {
+			case <-mantisCtx.Done():
+				return
+			case <-time.After(watchInterval):
+			}
+		}
+	},
+}
+
+// ticketPoll is the outcome of one poll of the ticket report.
+type ticketPoll struct {
+	Prev    []mantis.TicketResponse
+	Curr    []mantis.TicketResponse
+	Changes []utils.TicketChange
+	// Baseline is set when there was no snapshot to compare against.
+	Baseline bool
+}
+
+// pollTicketChanges fetches the report and the texts of the watched tickets,
+// diffs them against the stored snapshots and replaces the snapshots.
+func pollTicketChanges(
+	opts *mantis.GetReportOptions,
+	snapshotFile string,
+	watched []string,
+) (ticketPoll, error) {
+	var poll ticketPoll
+	prev, prevErr := cache.ReadFromCache[mantis.TicketResponse](snapshotFile)
+	curr, err := mantisClient.Dashboard.GetReport(mantisCtx, opts)
+	if err != nil {
+		return poll, err
+	}
+	poll.Prev, poll.Curr, poll.Baseline = prev, curr, prevErr != nil
+
+	if !poll.Baseline {
+		poll.Changes = utils.DiffTickets(prev, curr)
+	}
+	if err := cache.WriteToCache(snapshotFile, curr); err != nil {
+		log.Print(i18n.T("Warning: failed to write ticket snapshot: %v", err))
+	}
+	// The fresh report is also the best answer for a plain 'tickets' run.
+	if err := cache.WriteToCache(fmt.Sprintf(cache.TicketsCacheFileName, opts.Signature()), curr); err != nil {
+		log.Print(i18n.T("Warning: failed to write to cache: %v", err))
+	}
+
+	byNumber := make(map[string]mantis.TicketResponse, len(curr))
+	for _, t := range curr {
+		byNumber[t.TicketNumber] = t
+	}
+
+	for _, ticketNo := range watched {
+		comments, err := pollNewComments(ticketNo, byNumber)
+		if err != nil {
+			if mantisCtx.Err() != nil {
+				return poll, mantisCtx.Err()
+			}
+			log.Print(i18n.T("Warning: failed to get texts of ticket %s: %v", ticketNo, err))
+			continue
+		}
+		poll.Changes = append(poll.Changes, comments...)
+	}
+
+	return poll, nil
+}
+
+// dispatchNotifications sends each notification, reporting delivery errors
+// without stopping.
+func dispatchNotifications(sinks []notify.Sink, notifications []notify.Notification) {
+	for _, n := range notifications {
+		if err := notify.Send(mantisCtx, sinks, n); err != nil {
+			log.Print(i18n.T("Warning: notification for ticket %s: %v", n.Ticket, err))
+		}
+	}
+}
+
+// pollNewComments returns the texts of a ticket added since the previous
+// poll. The first poll of a ticket only records its texts.
+func pollNewComments(
+	ticketNo string,
+	report map[string]mantis.TicketResponse,
+) ([]utils.TicketChange, error) {
+	resp, err := fetchSupportInfo(ticketNo)
+	if err != nil {
+		return nil, err
+	}
+
+	textsFile := fmt.Sprintf(cache.WatchTextsCacheFileName, ticketNo)
+	prev, prevErr := cache.ReadFromCache[mantis.Text](textsFile)
+	if err := cache.WriteToCache(textsFile, resp.Texts); err != nil {
+		log.Print(i18n.T("Warning: failed to write text snapshot: %v", err))
+	}
+	if prevErr != nil {
+		return nil, nil
+	}
+
+	t, ok := report[ticketNo]
+	if !ok {
+		t = mantis.TicketResponse{
+			TicketNumber: ticketNo,
+			Status:       resp.UserStatusDescription,
+			Priority:     resp.Priority,
+			Description:  resp.Description,
+		}
+	}
+
+	var changes []utils.TicketChange
+	for _, tx := range utils.NewTexts(prev, resp.Texts) {
+		changes = append(changes, utils.TicketChange{Kind: utils.ChangeComment, Ticket: t, Text: &tx})
+	}
+	return changes, nil
+}
+
+func printTicketChanges(changes []utils.TicketChange) {
+	for _, c := range changes {
+		number := utils.TitleStyle.Sprint(c.Ticket.TicketNumber)
+		desc := truncate(c.Ticket.Description, 60)
+
+		switch c.Kind {
+		case utils.ChangeNew:
+			fmt.Println(i18n.T("%s %s new  %s  SLA %s  %s",
+				utils.SuccessStyle.Sprint("+"), number,
+				colorPriority(c.Ticket.Priority), colorSLA(c.Ticket.PercSLA), desc))
+		case utils.ChangeGone:
+			fmt.Println(i18n.T("%s %s left the report  %s", utils.MutedStyle.Sprint("-"), number, desc))
+		case utils.ChangeStatus:
+			fmt.Println(i18n.T("~ %s status %s → %s  %s", number, c.From, c.To, desc))
+		case utils.ChangePriority:
+			fmt.Println(i18n.T("~ %s priority %s → %s  %s", number, colorPriority(c.From), colorPriority(c.To), desc))
+		case utils.ChangeSLA:
+			fmt.Println(i18n.T("%s %s SLA %s → %s  %s",
+				utils.ErrorStyle.Sprint("!"), number, colorSLA(c.From), colorSLA(c.To), desc))
+		case utils.ChangeComment:
+			author := c.Text.TDFUser
+			if c.Text.UserInformation != nil && c.Text.UserInformation.Name != "" {
+				author = c.Text.UserInformation.Name
+			}
+			fmt.Println(i18n.T("%s %s new comment by %s %s",
+				utils.SectionStyle.Sprint("»"), number, author,
+				utils.MutedStyle.Sprint(c.Text.TDFCreatedAt.Format("2006-01-02 15:04"))))
+			body := formatTextBlock(stripHTML(c.Text.Text), 86)
+			fmt.Println("    " + strings.ReplaceAll(body, "\n", "\n    "))
+		}
+	}
+}
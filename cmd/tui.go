@@ -0,0 +1,722 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
+	"github.com/Salvadego/IntraCLI/tui"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+	"github.com/spf13/cobra"
+)
+
+// timesheetTUIKeys is shown in the help of the command and of the screen.
+const timesheetTUIKeys = `  ←→ ↑↓ h l k j  move a day / a week      [ ]   previous / next month
+  t              today                      v     week / month view
+  Enter Tab      go to the day's entries    Esc   back to the grid
+  a              add an entry on the day
+  e              edit the selected entry    y     duplicate it
+  d              delete / undelete it       u     undo its pending change
+  s              review and save the pending changes
+  r              reload from Mantis (discards pending changes)
+  q Ctrl-C       quit
+`
+
+// cellWidth is the width of a day in the month grid.
+const cellWidth = 11
+
+var (
+	tuiYear   int
+	tuiMonth  int
+	tuiWeek   bool
+	tuiMonday bool
+	tuiForce  bool
+)
+
+func init() {
+	n := time.Now()
+	timesheetTUICmd.Flags().IntVar(&tuiYear, "year", n.Year(), "Year")
+	timesheetTUICmd.Flags().IntVar(&tuiMonth, "month", int(n.Month()), "Month (1-12)")
+	timesheetTUICmd.Flags().BoolVarP(&tuiWeek, "week", "w", false, "Start in the week view")
+	timesheetTUICmd.Flags().BoolVar(&tuiMonday, "monday", false, "Week starts on Monday")
+	timesheetTUICmd.Flags().BoolVarP(&tuiForce, "force", "f", false, "Force refresh")
+
+	rootCmd.AddCommand(timesheetTUICmd)
+}
+
+var timesheetTUICmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Edit your timesheets in a full-screen calendar",
+	Long: `Shows your appointments on a month (or week) grid colored like 'cal'.
+Entries can be added, edited, duplicated and deleted in place; nothing is
+sent to Mantis until the pending changes are reviewed and saved with 's'.
+
+Saving deletes, recreates (for edits) and creates entries the same way as
+delete-timesheet, edit-timesheet and appoint. The entries replaced or
+deleted are kept for 'intracli undo-timesheet'.
+
+Keys:
+` + timesheetTUIKeys + `
+Examples:
+  intracli tui
+  intracli tui --week --monday
+  intracli tui --year 2026 --month 9`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if tuiMonth < 1 || tuiMonth > 12 {
+			log.Fatal(i18n.T("Invalid --month %d: expected 1-12", tuiMonth))
+		}
+		profile, err := getCurrentProfile(appConfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		today := time.Now()
+		cursor := time.Date(tuiYear, time.Month(tuiMonth), 1, 0, 0, 0, 0, time.Local)
+		if tuiYear == today.Year() && tuiMonth == int(today.Month()) {
+			cursor = cursor.AddDate(0, 0, today.Day()-1)
+		}
+
+		e := &timesheetEditor{
+			profile:     profile,
+			renderer:    Renderer{Monday: tuiMonday, Now: today},
+			loaded:      map[YearMonth]bool{},
+			nonBusiness: map[YearMonth]map[int]mantis.NonBusinessDay{},
+			cursor:      cursor,
+			week:        tuiWeek,
+		}
+		if err := e.load(YearMonth{Year: cursor.Year(), Month: cursor.Month()}, tuiForce); err != nil {
+			log.Fatal(i18n.T("Error getting timesheets: %v", err))
+		}
+
+		screen, err := tui.Open()
+		if err != nil {
+			log.Fatal(err)
+		}
+		e.screen = screen
+		err = e.run()
+		screen.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// draft is an appointment as edited in the TUI. orig is the entry in
+// Mantis, nil for entries added in the TUI.
+type draft struct {
+	orig    *mantis.TimesheetsResponse
+	entry   TimesheetEntry
+	deleted bool
+}
+
+// state is the pending change of d: '+' add, '-' delete, '~' edit, or ' '.
+func (d *draft) state() rune {
+	switch {
+	case d.orig == nil:
+		return '+'
+	case d.deleted:
+		return '-'
+	case d.entry != timesheetEntryOf(*d.orig):
+		return '~'
+	}
+	return ' '
+}
+
+type timesheetEditor struct {
+	screen   *tui.Screen
+	profile  config.Profile
+	renderer Renderer
+
+	drafts      []*draft
+	loaded      map[YearMonth]bool
+	nonBusiness map[YearMonth]map[int]mantis.NonBusinessDay
+
+	cursor    time.Time
+	week      bool
+	focusList bool
+	item      int
+
+	form      *tui.Form
+	formDraft *draft
+	review    bool
+	help      bool
+	// armed is a key that must be pressed again to confirm, e.g. 'q' with
+	// pending changes.
+	armed   rune
+	message string
+}
+
+// load reads the calendar month ym, replacing the entries already loaded
+// for it. Pending changes are kept.
+func (e *timesheetEditor) load(ym YearMonth, force bool) error {
+	first := time.Date(ym.Year, ym.Month, 1, 0, 0, 0, 0, time.Local)
+	r := dateRange{From: first, To: first.AddDate(0, 1, -1)}
+
+	fetcher := newMonthFetcher(force)
+	fetcher.Progress = nil
+	periods := r.periods()
+	byPeriod, err := fetcher.Timesheets(mantisCtx, periods)
+	if err != nil {
+		return err
+	}
+	results := make([][]mantis.TimesheetsResponse, 0, len(periods))
+	for _, p := range periods {
+		results = append(results, byPeriod[p])
+	}
+
+	nbMap := map[int]mantis.NonBusinessDay{}
+	nb, err := fetcher.NonBusinessDays(mantisCtx, []YearMonth{ym})
+	if err != nil && mantisCtx.Err() != nil {
+		return err
+	}
+	for _, d := range nb[ym] {
+		nbMap[d.Date.Day()] = d
+	}
+
+	drafts := make([]*draft, 0, len(e.drafts))
+	for _, d := range e.drafts {
+		if d.orig == nil || !r.contains(parseDay(d.orig.DateDoc[:10])) {
+			drafts = append(drafts, d)
+		}
+	}
+	for _, ts := range mergeTimesheets(results, r) {
+		drafts = append(drafts, &draft{orig: &ts, entry: timesheetEntryOf(ts)})
+	}
+
+	e.drafts = drafts
+	e.nonBusiness[ym] = nbMap
+	e.loaded[ym] = true
+	return nil
+}
+
+// ensure loads the calendar month of day unless it is loaded already.
+func (e *timesheetEditor) ensure(day time.Time) {
+	ym := YearMonth{Year: day.Year(), Month: day.Month()}
+	if e.loaded[ym] {
+		return
+	}
+	e.status(i18n.T("Loading %s…", fmt.Sprintf("%s %d", i18n.Month(ym.Month), ym.Year)))
+	if err := e.load(ym, false); err != nil {
+		e.message = i18n.T("Error getting timesheets: %v", err)
+		return
+	}
+	e.message = ""
+}
+
+// reload refetches every loaded month and drops the pending changes.
+func (e *timesheetEditor) reload() error {
+	e.drafts = nil
+	for ym := range e.loaded {
+		if err := e.load(ym, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseDay(s string) time.Time {
+	t, _ := time.ParseInLocation("2006-01-02", s, time.Local)
+	return t
+}
+
+func (e *timesheetEditor) pending() []*draft {
+	var out []*draft
+	for _, d := range e.drafts {
+		if d.state() != ' ' {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// dayDrafts returns the entries of a day, deleted ones included.
+func (e *timesheetEditor) dayDrafts(day time.Time) []*draft {
+	key := day.Format("2006-01-02")
+	var out []*draft
+	for _, d := range e.drafts {
+		if d.entry.Date == key {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// monthDays builds the DayInfo of a calendar month from the drafts, so the
+// totals include the pending changes.
+func (e *timesheetEditor) monthDays(year int, month time.Month) []DayInfo {
+	hoursByDate := map[string]float64{}
+	dateAppointments := map[string][]mantis.TimesheetsResponse{}
+	for _, d := range e.drafts {
+		if d.deleted || !inCalendarMonth(parseDay(d.entry.Date), year, month) {
+			continue
+		}
+		hoursByDate[d.entry.Date] += d.entry.Hours
+		dateAppointments[d.entry.Date] = append(dateAppointments[d.entry.Date], mantis.TimesheetsResponse{
+			TimesheetType:  d.entry.TimesheetType,
+			Quantity:       d.entry.Hours,
+			SalesOrderLine: int64(d.entry.SalesOrderLine),
+			SalesOrder:     int64(d.entry.SalesOrder),
+			TicketNo:       d.entry.TicketNo,
+			Description:    d.entry.Description,
+			DateDoc:        d.entry.Date + "T00:00:00Z",
+		})
+	}
+	ym := YearMonth{Year: year, Month: month}
+	return buildDays(year, month, hoursByDate, dateAppointments, e.nonBusiness[ym], e.renderer.Now)
+}
+
+func (e *timesheetEditor) dayInfo(day time.Time) DayInfo {
+	return e.monthDays(day.Year(), day.Month())[day.Day()-1]
+}
+
+func (e *timesheetEditor) run() error {
+	for {
+		if err := e.screen.Draw(e.view()); err != nil {
+			return err
+		}
+		k, err := e.screen.ReadKey()
+		if err != nil {
+			return err
+		}
+		quit, err := e.handle(k)
+		if err != nil || quit {
+			return err
+		}
+	}
+}
+
+// status draws the screen with a message before a slow operation.
+func (e *timesheetEditor) status(msg string) {
+	e.message = msg
+	e.screen.Draw(e.view())
+}
+
+func (e *timesheetEditor) moveTo(day time.Time) {
+	if !sameDay(day, e.cursor) {
+		e.item = 0
+	}
+	e.cursor = day
+	e.ensure(day)
+}
+
+func (e *timesheetEditor) handle(k tui.Key) (bool, error) {
+	armed := e.armed
+	e.armed = 0
+	if k.Code != tui.KeyResize {
+		e.message = ""
+	}
+
+	switch {
+	case e.form != nil:
+		e.handleForm(k)
+		return false, nil
+	case e.help:
+		e.help = false
+		return false, nil
+	case e.review:
+		return false, e.handleReview(k)
+	}
+
+	items := e.dayDrafts(e.cursor)
+	switch {
+	case k.IsCtrl('c') || k.Is('q'):
+		if n := len(e.pending()); n > 0 && armed != 'q' {
+			e.armed = 'q'
+			e.message = i18n.T("%d pending change(s) not saved: press q again to discard them.", n)
+			return false, nil
+		}
+		return true, nil
+	case k.Is('?'):
+		e.help = true
+	case k.Is('v'):
+		e.week = !e.week
+	case k.Is('t'):
+		e.moveTo(truncateDay(time.Now()))
+	case k.Is('['):
+		e.moveTo(addMonthsClamped(e.cursor, -1))
+	case k.Is(']'):
+		e.moveTo(addMonthsClamped(e.cursor, 1))
+	case k.Is('a'):
+		e.openForm(i18n.T("Add an entry on %s", e.cursor.Format("2006-01-02")), nil, nil)
+	case k.Is('s'):
+		if len(e.pending()) == 0 {
+			e.message = i18n.T("Nothing to save.")
+		} else {
+			e.review = true
+		}
+	case k.Is('r'):
+		if n := len(e.pending()); n > 0 && armed != 'r' {
+			e.armed = 'r'
+			e.message = i18n.T("Reloading discards %d pending change(s): press r again to confirm.", n)
+			return false, nil
+		}
+		e.status(i18n.T("Reloading…"))
+		if err := e.reload(); err != nil {
+			e.message = i18n.T("Error getting timesheets: %v", err)
+		} else {
+			e.message = i18n.T("Reloaded.")
+		}
+
+	case e.focusList:
+		e.handleList(k, items)
+
+	case k.Code == tui.KeyLeft || k.Is('h'):
+		e.moveTo(e.cursor.AddDate(0, 0, -1))
+	case k.Code == tui.KeyRight || k.Is('l'):
+		e.moveTo(e.cursor.AddDate(0, 0, 1))
+	case k.Code == tui.KeyUp || k.Is('k'):
+		e.moveTo(e.cursor.AddDate(0, 0, -7))
+	case k.Code == tui.KeyDown || k.Is('j'):
+		e.moveTo(e.cursor.AddDate(0, 0, 7))
+	case k.Code == tui.KeyEnter || k.Code == tui.KeyTab:
+		e.focusList = true
+	default:
+		e.handleItem(k, items)
+	}
+	return false, nil
+}
+
+// handleList moves through the entries of the selected day.
+func (e *timesheetEditor) handleList(k tui.Key, items []*draft) {
+	switch {
+	case k.Code == tui.KeyEsc || k.Code == tui.KeyTab:
+		e.focusList = false
+	case k.Code == tui.KeyUp || k.Is('k'):
+		e.item = max(0, e.item-1)
+	case k.Code == tui.KeyDown || k.Is('j'):
+		e.item = min(max(0, len(items)-1), e.item+1)
+	case k.Code == tui.KeyEnter:
+		e.handleItem(tui.Key{Code: tui.KeyRune, Rune: 'e'}, items)
+	default:
+		e.handleItem(k, items)
+	}
+}
+
+// handleItem runs the keys acting on the selected entry.
+func (e *timesheetEditor) handleItem(k tui.Key, items []*draft) {
+	if e.item >= len(items) {
+		return
+	}
+	d := items[e.item]
+	switch {
+	case k.Is('e'):
+		if d.deleted {
+			e.message = i18n.T("Undelete the entry (d) before editing it.")
+			return
+		}
+		e.openForm(i18n.T("Edit entry"), d, d)
+	case k.Is('y'):
+		e.openForm(i18n.T("Duplicate entry"), d, nil)
+	case k.Is('d'):
+		if d.orig == nil {
+			e.removeDraft(d)
+			e.item = max(0, min(e.item, len(items)-2))
+			return
+		}
+		d.deleted = !d.deleted
+	case k.Is('u'):
+		if d.orig == nil {
+			e.removeDraft(d)
+			e.item = max(0, min(e.item, len(items)-2))
+			return
+		}
+		d.deleted = false
+		d.entry = timesheetEntryOf(*d.orig)
+	}
+}
+
+func (e *timesheetEditor) removeDraft(d *draft) {
+	for i, x := range e.drafts {
+		if x == d {
+			e.drafts = append(e.drafts[:i], e.drafts[i+1:]...)
+			return
+		}
+	}
+}
+
+// openForm opens the entry form, prefilled from src when given. The form
+// edits target, or adds a new entry when target is nil.
+func (e *timesheetEditor) openForm(title string, src, target *draft) {
+	f := newAppointForm(title, e.profile, "")
+	updateAppointForm(f, e.profile, func() { f.Set("Date", e.cursor.Format("2006-01-02")) })
+	if src != nil {
+		f.Set("Hours", strconv.FormatFloat(src.entry.Hours, 'f', -1, 64))
+		f.Set("Description", src.entry.Description)
+		f.Set("Ticket", src.entry.TicketNo)
+		f.Set("Project", projectAliasFor(e.profile, src.entry.SalesOrder, src.entry.SalesOrderLine))
+		f.Set("Type", timesheetTypeName(src.entry.TimesheetType))
+		f.Set("Date", src.entry.Date)
+	}
+	e.form, e.formDraft = f, target
+}
+
+func (e *timesheetEditor) handleForm(k tui.Key) {
+	var res tui.FormResult
+	updateAppointForm(e.form, e.profile, func() { res = e.form.Handle(k) })
+	switch res {
+	case tui.FormCancelled:
+		e.form = nil
+	case tui.FormSubmitted:
+		entry, err := appointFormEntry(e.form, e.profile)
+		if err != nil {
+			e.form.Message = err.Error()
+			return
+		}
+		if e.formDraft != nil {
+			e.formDraft.entry = entry
+		} else {
+			e.drafts = append(e.drafts, &draft{entry: entry})
+		}
+		e.form = nil
+		e.moveTo(parseDay(entry.Date))
+		e.message = i18n.T("%d pending change(s); press s to review and save.", len(e.pending()))
+	}
+}
+
+func (e *timesheetEditor) handleReview(k tui.Key) error {
+	switch {
+	case k.Is('y'):
+		e.review = false
+		if err := e.screen.Suspend(e.save); err != nil {
+			return err
+		}
+		e.status(i18n.T("Reloading…"))
+		if err := e.reload(); err != nil {
+			e.message = i18n.T("Error getting timesheets: %v", err)
+		} else {
+			e.message = i18n.T("Saved.")
+		}
+	case k.Is('n') || k.Code == tui.KeyEsc || k.Is('q'):
+		e.review = false
+	}
+	return nil
+}
+
+// save sends the pending changes. Edits delete the old entry and create
+// the new one, as edit-timesheet does; the replaced and deleted entries are
+// kept for undo-timesheet.
+func (e *timesheetEditor) save() {
+	client, ctx := mantisClient, mantisCtx
+
+	var undo []mantis.TimesheetsResponse
+	for _, d := range e.pending() {
+		if d.orig != nil {
+			undo = append(undo, *d.orig)
+		}
+	}
+	if len(undo) > 0 {
+		if err := cache.WriteToCache("undo_timesheets.json", undo); err != nil {
+			log.Print(i18n.T("Warning: failed to write to cache: %v", err))
+		}
+	}
+
+	for _, d := range e.pending() {
+		switch d.state() {
+		case '-':
+			fmt.Println(i18n.T("Attempting to delete timesheet: %d", d.orig.TimesheetID))
+			if err := client.Timesheet.DeleteTimesheet(ctx, d.orig.TimesheetID); err != nil {
+				log.Print(i18n.T("Error deleting timesheet %d: %v", d.orig.TimesheetID, err))
+			}
+		case '~':
+			if err := client.Timesheet.DeleteTimesheet(ctx, d.orig.TimesheetID); err != nil {
+				log.Print(i18n.T("Failed to delete timesheet %d: %v", d.orig.TimesheetID, err))
+				continue
+			}
+			fmt.Println(i18n.T("Recreating timesheet: %+v", d.entry))
+			appoint(client, currentUserID, d.entry, ctx)
+		case '+':
+			fmt.Println(i18n.T("Attempting to create appointment:\n%+v", d.entry))
+			appoint(client, currentUserID, d.entry, ctx)
+		}
+	}
+}
+
+func (e *timesheetEditor) view() []string {
+	w, h := e.screen.Size()
+
+	pending := map[rune]int{}
+	for _, d := range e.pending() {
+		pending[d.state()]++
+	}
+	month := 0.0
+	for _, d := range e.monthDays(e.cursor.Year(), e.cursor.Month()) {
+		month += d.Hours
+	}
+	title := fmt.Sprintf(" IntraCLI timesheets  %s %d  %s", i18n.Month(e.cursor.Month()), e.cursor.Year(), i18n.Hours(month))
+	if len(pending) > 0 {
+		title += "  " + i18n.T("pending: +%d ~%d -%d", pending['+'], pending['~'], pending['-'])
+	}
+	lines := []string{tui.Reverse(title, w), ""}
+
+	switch {
+	case e.form != nil:
+		lines = append(lines, e.form.Lines(w)...)
+	case e.help:
+		lines = append(lines, strings.Split(i18n.T("Keys:")+"\n"+timesheetTUIKeys, "\n")...)
+	case e.review:
+		lines = append(lines, e.reviewLines()...)
+	default:
+		lines = append(lines, e.gridLines()...)
+		lines = append(lines, "")
+		lines = append(lines, e.dayLines(w, h-len(lines)-1)...)
+	}
+
+	for len(lines) < h-1 {
+		lines = append(lines, "")
+	}
+	lines = lines[:max(0, h-1)]
+
+	footer := e.message
+	if footer == "" {
+		footer = i18n.T("arrows move  [ ] month  v view  ⏎ entries  a add  e edit  y duplicate  d delete  s save  ? help  q quit")
+		footer = utils.MutedStyle.Sprint(footer)
+	}
+	return append(lines, " "+footer)
+}
+
+// gridLines draws the month of the cursor, or only its week.
+func (e *timesheetEditor) gridLines() []string {
+	var header strings.Builder
+	for _, l := range e.renderer.weekdayLabels() {
+		header.WriteString(tui.Pad(center(l, cellWidth-1), cellWidth))
+	}
+	lines := []string{header.String()}
+
+	weekStart := time.Sunday
+	if e.renderer.Monday {
+		weekStart = time.Monday
+	}
+	start := e.cursor
+	for start.Weekday() != weekStart {
+		start = start.AddDate(0, 0, -1)
+	}
+	weeks := 1
+	if !e.week {
+		first := time.Date(e.cursor.Year(), e.cursor.Month(), 1, 0, 0, 0, 0, time.Local)
+		start = first
+		for start.Weekday() != weekStart {
+			start = start.AddDate(0, 0, -1)
+		}
+		last := first.AddDate(0, 1, -1)
+		weeks = (last.Day() + int(first.Sub(start).Hours()/24) + 6) / 7
+	}
+
+	for wk := range weeks {
+		var row strings.Builder
+		for i := range 7 {
+			day := start.AddDate(0, 0, wk*7+i)
+			if !e.week && day.Month() != e.cursor.Month() {
+				row.WriteString(strings.Repeat(" ", cellWidth))
+				continue
+			}
+			row.WriteString(e.dayCell(day))
+		}
+		lines = append(lines, row.String())
+	}
+	return lines
+}
+
+func (e *timesheetEditor) dayCell(day time.Time) string {
+	ym := YearMonth{Year: day.Year(), Month: day.Month()}
+	if !e.loaded[ym] {
+		return tui.Pad(fmt.Sprintf(" %02d", day.Day()), cellWidth)
+	}
+	d := e.dayInfo(day)
+	hours := ""
+	if d.Hours > 0 {
+		hours = i18n.Hours(d.Hours)
+	}
+	cell := fmt.Sprintf(" %02d %6s", day.Day(), hours)
+
+	color := e.renderer.dayColor(d, utils.JourneyFor(e.profile, day))
+	switch {
+	case sameDay(day, e.cursor):
+		return INVERT + color + cell + RESET + " "
+	case d.IsToday:
+		return BOLD + color + cell + RESET + " "
+	}
+	return color + cell + RESET + " "
+}
+
+// dayLines lists the entries of the selected day in at most height lines.
+func (e *timesheetEditor) dayLines(width, height int) []string {
+	d := e.dayInfo(e.cursor)
+	heading := i18n.Weekday(e.cursor.Weekday()) + " " + i18n.LongDate(e.cursor)
+	journey := utils.JourneyFor(e.profile, e.cursor)
+	total := e.renderer.colorForHours(journey, d.Hours) + i18n.Hours(d.Hours) + RESET
+	total += " " + i18n.T("of %s", i18n.Hours(journey))
+	lines := []string{BOLD + heading + RESET + "  " + total}
+	if d.IsHoliday {
+		nb := e.nonBusiness[YearMonth{Year: e.cursor.Year(), Month: e.cursor.Month()}][e.cursor.Day()]
+		lines = append(lines, utils.SGR(utils.ActiveTheme.Holiday)+i18n.T("Non-business day: %s", nb.Name)+RESET)
+	}
+
+	items := e.dayDrafts(e.cursor)
+	if len(items) == 0 {
+		lines = append(lines, "", "  "+i18n.T("No entries. Press a to add one."))
+	}
+	e.item = min(e.item, max(0, len(items)-1))
+
+	offset := tui.Scroll(0, e.item, max(1, height-len(lines)), len(items))
+	for i := offset; i < len(items) && len(lines) < height; i++ {
+		it := items[i]
+		id := i18n.T("new")
+		if it.orig != nil {
+			id = strconv.Itoa(it.orig.TimesheetID)
+		}
+		line := fmt.Sprintf(" %c %-9s %7s  %-10s %-12s %-10s %s",
+			it.state(), id, i18n.Hours(it.entry.Hours), it.entry.TicketNo,
+			projectAliasFor(e.profile, it.entry.SalesOrder, it.entry.SalesOrderLine),
+			timesheetTypeName(it.entry.TimesheetType),
+			strings.Join(strings.Fields(it.entry.Description), " "))
+		switch {
+		case i == e.item && e.focusList:
+			line = tui.Reverse(line, width)
+		case it.deleted:
+			line = "\033[9m" + utils.SGR(utils.ActiveTheme.Muted) + line + RESET
+		case i == e.item:
+			line = tui.Bold(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// reviewLines lists the pending changes before saving them.
+func (e *timesheetEditor) reviewLines() []string {
+	lines := []string{BOLD + i18n.T("Save these changes to Mantis?") + RESET, ""}
+	describe := func(t TimesheetEntry) string {
+		return fmt.Sprintf("%s %s %s %s %q", t.Date, i18n.Hours(t.Hours), t.TicketNo,
+			projectAliasFor(e.profile, t.SalesOrder, t.SalesOrderLine), t.Description)
+	}
+	for _, d := range e.pending() {
+		switch d.state() {
+		case '+':
+			lines = append(lines, utils.SuccessStyle.Sprintf("  + %-8s", i18n.T("add"))+describe(d.entry))
+		case '-':
+			lines = append(lines, utils.ErrorStyle.Sprintf("  - %-8s#%d ", i18n.T("delete"), d.orig.TimesheetID)+describe(d.entry))
+		case '~':
+			lines = append(lines,
+				utils.TitleStyle.Sprintf("  ~ %-8s#%d ", i18n.T("edit"), d.orig.TimesheetID)+describe(timesheetEntryOf(*d.orig)),
+				"                → "+describe(d.entry))
+		}
+	}
+	return append(lines, "", "  "+i18n.T("y save   n/Esc back"))
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// addMonthsClamped moves t by n months, keeping the day within the month.
+func addMonthsClamped(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, time.Local)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
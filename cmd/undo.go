@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/Salvadego/IntraCLI/cache"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/mantis/mantis"
 	"github.com/spf13/cobra"
 )
@@ -23,10 +24,10 @@ var undoCmd = &cobra.Command{
 
 		cached, err := cache.ReadFromCache[mantis.TimesheetsResponse](toDeleteCacheFile)
 		if err != nil {
-			log.Fatalf("Failed to read to delete cache: %v", err)
+			log.Fatal(i18n.T("Failed to read to delete cache: %v", err))
 		}
 		if len(cached) == 0 {
-			fmt.Println("No to delete information available.")
+			fmt.Println(i18n.T("No to delete information available."))
 			return
 		}
 
@@ -36,7 +37,7 @@ var undoCmd = &cobra.Command{
 			client.Timesheet.DeleteTimesheet(ctx, ts.TimesheetID)
 		}
 
-		fmt.Println("Deletion completed.")
+		fmt.Println(i18n.T("Deletion completed."))
 		toDeleteCachePath, _ := cache.GetCacheFilePath(toDeleteCacheFile)
 		_ = os.Remove(toDeleteCachePath)
 
@@ -44,30 +45,22 @@ var undoCmd = &cobra.Command{
 
 		cached, err = cache.ReadFromCache[mantis.TimesheetsResponse](undoCacheFile)
 		if err != nil {
-			log.Fatalf("Failed to read undo cache: %v", err)
+			log.Fatal(i18n.T("Failed to read undo cache: %v", err))
 		}
 		if len(cached) == 0 {
-			fmt.Println("No undo information available.")
+			fmt.Println(i18n.T("No undo information available."))
 			return
 		}
 
 		for _, ts := range cached {
-			entry := TimesheetEntry{
-				Date:           ts.DateDoc[:10],
-				Description:    ts.Description,
-				TicketNo:       ts.TicketNo,
-				TimesheetType:  ts.TimesheetType,
-				Hours:          ts.Quantity,
-				SalesOrder:     int(ts.SalesOrder),
-				SalesOrderLine: int(ts.SalesOrderLine),
-			}
-			fmt.Printf("Restoring timesheet: %+v\n", entry)
+			entry := timesheetEntryOf(ts)
+			fmt.Println(i18n.T("Restoring timesheet: %+v", entry))
 			appoint(client, currentUserID, entry, ctx)
 		}
 
-		fmt.Println("Undo completed.")
+		fmt.Println(i18n.T("Undo completed."))
 
-		fmt.Printf("Removing undo cache file: %s\n", undoCacheFile)
+		fmt.Println(i18n.T("Removing undo cache file: %s", undoCacheFile))
 
 		cachePath, _ := cache.GetCacheFilePath(undoCacheFile)
 		_ = os.Remove(cachePath)
@@ -21,6 +21,38 @@ type Config struct {
 	Editor          string             `yaml:"editor"`
 	SavedFilters    map[string]string  `yaml:"savedFilters"`
 	SavedDayFilters map[string]string  `yaml:"savedDayFilters"`
+	// SavedTicketFilters are the named queries of `tickets --filter`.
+	SavedTicketFilters map[string]string `yaml:"savedTicketFilters,omitempty"`
+	// Language is the locale of dates, numbers and messages, e.g. en_US.
+	// Empty follows LANG.
+	Language string `yaml:"language,omitempty"`
+	// MantisLanguage is the language Mantis answers in, e.g. en_US. It
+	// names the timesheet types -T and alias defaults refer to, so it does
+	// not follow Language. Empty is pt_BR.
+	MantisLanguage string `yaml:"mantisLanguage,omitempty"`
+	// Theme names the color theme: a built-in one (default, light,
+	// colorblind) or one of Themes.
+	Theme  string           `yaml:"theme,omitempty"`
+	Themes map[string]Theme `yaml:"themes,omitempty"`
+	// ColorDepth forces truecolor, 256 or 16 colors instead of detecting
+	// it from COLORTERM and TERM.
+	ColorDepth string `yaml:"colorDepth,omitempty"`
+	// FetchConcurrency bounds parallel month fetches (0 uses the default).
+	FetchConcurrency int `yaml:"fetchConcurrency,omitempty"`
+	// Notifications configures `tickets watch --notify` and `intracli daemon`.
+	Notifications NotifyConfig `yaml:"notifications,omitempty"`
+}
+
+// NotifyConfig selects the notification sinks and what triggers them.
+type NotifyConfig struct {
+	// Sinks is any of bell, desktop, command, webhook (default bell).
+	Sinks   []string `yaml:"sinks,omitempty"`
+	Command string   `yaml:"command,omitempty"`
+	Webhook string   `yaml:"webhook,omitempty"`
+	// SLAThresholds are percentages; crossing below one notifies (default 50).
+	SLAThresholds []int `yaml:"slaThresholds,omitempty"`
+	// Statuses notify when a ticket changes to one of them.
+	Statuses []string `yaml:"statuses,omitempty"`
 }
 
 type Profile struct {
@@ -31,13 +63,89 @@ type Profile struct {
 	Email          string                  `yaml:"email"`
 	EmployeeCode   int                     `yaml:"employeeCode"`
 	DailyJourney   float64                 `yaml:"dailyJourney"`
+	DailyHardCap   float64                 `yaml:"dailyHardCap,omitempty"`
 	ProjectAliases map[string]ProjectAlias `yaml:"projectAliases"`
+
+	// Connection settings of the profile. Empty values fall back to the
+	// global baseURL, roleID and language.
+	BaseURL  string `yaml:"baseURL,omitempty"`
+	RoleID   int    `yaml:"roleID,omitempty"`
+	Language string `yaml:"language,omitempty"`
+	// CredentialRef says where the login comes from: env:PREFIX reads
+	// PREFIX_USERNAME and PREFIX_PASSWORD, cmd:COMMAND runs COMMAND and
+	// reads the username and password from its first two lines. Empty uses
+	// MANTIS_USERNAME and MANTIS_PASSWORD.
+	CredentialRef string `yaml:"credentialRef,omitempty"`
+
+	// Tickets tracked by `intracli budget` and `intracli tickets watch`.
+	WatchedTickets []string `yaml:"watchedTickets,omitempty"`
+	// BudgetThreshold flags watched tickets with fewer remaining hours.
+	BudgetThreshold float64 `yaml:"budgetThreshold,omitempty"`
+
+	// Hour bank settings used by `intracli balance`.
+	OpeningBalances  []OpeningBalance  `yaml:"openingBalances,omitempty"`
+	JourneyOverrides []JourneyOverride `yaml:"journeyOverrides,omitempty"`
+}
+
+// OpeningBalance is a known hour-bank balance at the start of Date
+// (YYYY-MM-DD), e.g. taken from an HR statement. Positive is surplus.
+type OpeningBalance struct {
+	Date  string  `yaml:"date"`
+	Hours float64 `yaml:"hours"`
+}
+
+// JourneyOverride replaces DailyJourney between From and To (inclusive,
+// YYYY-MM-DD). An empty To leaves the override open-ended.
+type JourneyOverride struct {
+	From  string  `yaml:"from"`
+	To    string  `yaml:"to,omitempty"`
+	Hours float64 `yaml:"hours"`
+}
+
+// Theme colors the output. Each color is a #rrggbb value or an ANSI name
+// (red, hiblack, ...), optionally with bold, underline or bg:COLOR. Unset
+// colors are taken from Base, a built-in theme (default if empty).
+type Theme struct {
+	Base string `yaml:"base,omitempty"`
+	// Gradient is the low, middle and high stop of the hours scale, as
+	// #rrggbb.
+	Gradient []string `yaml:"gradient,omitempty"`
+	Holiday  string   `yaml:"holiday,omitempty"`
+	Weekend  string   `yaml:"weekend,omitempty"`
+	Today    string   `yaml:"today,omitempty"`
+	// Missing marks past business days without hours.
+	Missing string `yaml:"missing,omitempty"`
+
+	Title   string `yaml:"title,omitempty"`
+	Section string `yaml:"section,omitempty"`
+	Error   string `yaml:"error,omitempty"`
+	Success string `yaml:"success,omitempty"`
+	Muted   string `yaml:"muted,omitempty"`
+	Match   string `yaml:"match,omitempty"`
+
+	PriorityHigh   string `yaml:"priorityHigh,omitempty"`
+	PriorityMedium string `yaml:"priorityMedium,omitempty"`
+	PriorityLow    string `yaml:"priorityLow,omitempty"`
+	SLABad         string `yaml:"slaBad,omitempty"`
+	SLAWarn        string `yaml:"slaWarn,omitempty"`
+	SLAGood        string `yaml:"slaGood,omitempty"`
 }
 
 type ProjectAlias struct {
 	SalesOrder     int  `yaml:"salesOrder"`
 	SalesOrderLine int  `yaml:"salesOrderLine"`
 	NeedsTicket    bool `yaml:"needsTicket"`
+	// TargetShare is the intended share of logged hours, in percent.
+	TargetShare float64 `yaml:"targetShare,omitempty"`
+
+	// Defaults for appointments on the alias; flags override them.
+	Ticket string `yaml:"ticket,omitempty"`
+	// Type is a timesheet type name, e.g. Normal.
+	Type string `yaml:"type,omitempty"`
+	// Description may use {{date}} and {{weekday}}.
+	Description string `yaml:"description,omitempty"`
+	// Hours is a duration such as 1h30m.
+	Hours string `yaml:"hours,omitempty"`
 }
 
 func GetConfigPath() (string, error) {
@@ -121,6 +229,30 @@ func InitializeConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// BaseURLFor is the Mantis URL of profile p.
+func (c *Config) BaseURLFor(p Profile) string {
+	if p.BaseURL != "" {
+		return p.BaseURL
+	}
+	return c.BaseURL
+}
+
+// RoleIDFor is the Mantis role of profile p.
+func (c *Config) RoleIDFor(p Profile) int {
+	if p.RoleID != 0 {
+		return p.RoleID
+	}
+	return c.RoleID
+}
+
+// LanguageFor is the configured language of profile p.
+func (c *Config) LanguageFor(p Profile) string {
+	if p.Language != "" {
+		return p.Language
+	}
+	return c.Language
+}
+
 func (c *Config) IsInitialized() bool {
 	if c.DefaultProfile == "" {
 		return false
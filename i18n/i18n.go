@@ -0,0 +1,172 @@
+// Package i18n holds the bundled locales: month and weekday names, date
+// and number formats, and the catalog of translated messages.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale is a bundled language.
+type Locale struct {
+	// Tag is the locale name, e.g. pt_BR. It is also sent to Mantis.
+	Tag           string
+	Months        [12]string
+	MonthsShort   [12]string
+	Weekdays      [7]string // Sunday first
+	WeekdaysShort [7]string // two letters, Sunday first
+	// DayMonth is the layout of numeric day and month, e.g. 02/01.
+	DayMonth string
+	// ShortDate and LongDate take the day, month name and year as
+	// explicit fmt arguments 1, 2 and 3.
+	ShortDate string
+	LongDate  string
+	Decimal   string
+	// messages maps the English message to its translation.
+	messages map[string]string
+}
+
+// DefaultTag is used when nothing selects a locale.
+const DefaultTag = "pt_BR"
+
+var locales = map[string]*Locale{
+	ptBR.Tag: &ptBR,
+	enUS.Tag: &enUS,
+}
+
+var current = &ptBR
+
+// Current is the locale in use.
+func Current() *Locale {
+	return current
+}
+
+// Tags lists the bundled locales.
+func Tags() []string {
+	return []string{ptBR.Tag, enUS.Tag}
+}
+
+// Lookup finds a bundled locale by tag. It accepts POSIX names such as
+// pt_BR.UTF-8, hyphenated tags and bare languages (en selects en_US).
+func Lookup(tag string) (*Locale, bool) {
+	tag, _, _ = strings.Cut(tag, ".")
+	tag, _, _ = strings.Cut(tag, "@")
+	tag = strings.ReplaceAll(tag, "-", "_")
+	if tag == "" {
+		return nil, false
+	}
+	for _, l := range locales {
+		if strings.EqualFold(l.Tag, tag) {
+			return l, true
+		}
+	}
+	lang, _, _ := strings.Cut(tag, "_")
+	for _, t := range Tags() {
+		if l := locales[t]; strings.EqualFold(l.Tag[:2], lang) {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+// Set selects the locale in use.
+func Set(tag string) error {
+	l, ok := Lookup(tag)
+	if !ok {
+		return fmt.Errorf("unknown language %q (available: %s)", tag, strings.Join(Tags(), ", "))
+	}
+	current = l
+	return nil
+}
+
+// Detect picks the locale from the first setting that names one: the
+// explicit choice, then LC_ALL, LC_MESSAGES and LANG. An explicit choice
+// that is not bundled is an error; unknown environment values are skipped.
+func Detect(explicit string) (string, error) {
+	if explicit != "" {
+		l, ok := Lookup(explicit)
+		if !ok {
+			return "", fmt.Errorf("unknown language %q (available: %s)", explicit, strings.Join(Tags(), ", "))
+		}
+		return l.Tag, nil
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if l, ok := Lookup(os.Getenv(env)); ok {
+			return l.Tag, nil
+		}
+	}
+	return DefaultTag, nil
+}
+
+// T translates msg and formats it with args like fmt.Sprintf. Messages
+// missing from the catalog are used as is.
+func T(msg string, args ...any) string {
+	if t, ok := current.messages[msg]; ok {
+		msg = t
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Errorf translates format and formats it with args like fmt.Errorf, so
+// %w still wraps.
+func Errorf(format string, args ...any) error {
+	if t, ok := current.messages[format]; ok {
+		format = t
+	}
+	return fmt.Errorf(format, args...)
+}
+
+// Month is the full name of m.
+func Month(m time.Month) string {
+	return current.Months[m-1]
+}
+
+// MonthShort is the three-letter name of m.
+func MonthShort(m time.Month) string {
+	return current.MonthsShort[m-1]
+}
+
+// Weekday is the full name of d.
+func Weekday(d time.Weekday) string {
+	return current.Weekdays[d]
+}
+
+// WeekdayShort is the two-letter name of d.
+func WeekdayShort(d time.Weekday) string {
+	return current.WeekdaysShort[d]
+}
+
+// DayMonth formats the numeric day and month of t.
+func DayMonth(t time.Time) string {
+	return t.Format(current.DayMonth)
+}
+
+// ShortDate formats t with the abbreviated month, e.g. 19 de out 2026.
+func ShortDate(t time.Time) string {
+	return fmt.Sprintf(current.ShortDate, t.Day(), MonthShort(t.Month()), t.Year())
+}
+
+// LongDate formats t with the full month name, e.g. 19 de Outubro 2026.
+func LongDate(t time.Time) string {
+	return fmt.Sprintf(current.LongDate, t.Day(), Month(t.Month()), t.Year())
+}
+
+// Float formats f with prec decimals and the locale's decimal separator.
+func Float(f float64, prec int) string {
+	s := strconv.FormatFloat(f, 'f', prec, 64)
+	if current.Decimal != "." {
+		s = strings.Replace(s, ".", current.Decimal, 1)
+	}
+	return s
+}
+
+// Hours formats an amount of hours, e.g. 7,50h.
+func Hours(f float64) string {
+	return Float(f, 2) + "h"
+}
@@ -0,0 +1,557 @@
+package i18n
+
+var ptBR = Locale{
+	Tag: "pt_BR",
+	Months: [12]string{
+		"Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho",
+		"Julho", "Agosto", "Setembro", "Outubro", "Novembro", "Dezembro",
+	},
+	MonthsShort: [12]string{
+		"jan", "fev", "mar", "abr", "mai", "jun",
+		"jul", "ago", "set", "out", "nov", "dez",
+	},
+	Weekdays: [7]string{
+		"Domingo", "Segunda", "Terça", "Quarta", "Quinta", "Sexta", "Sábado",
+	},
+	WeekdaysShort: [7]string{"do", "se", "te", "qu", "qu", "se", "sá"},
+	DayMonth:      "02/01",
+	ShortDate:     "%[1]d de %[2]s %[3]d",
+	LongDate:      "%[1]d de %[2]s %[3]d",
+	Decimal:       ",",
+	messages: map[string]string{
+		// cal
+		"--- Appointments for %s ---": "--- Apontamentos para %s ---",
+		"Non-business day: %s":        "Dia não útil: %s",
+		"No appointments found.":      "Apontamentos não encontrados.",
+		"Hours":                       "Horas",
+		"Description":                 "Descrição",
+		"Week %d: %s of %s":           "Semana %d: %s de %s",
+		"Total %s: %s of %s":          "Total %s: %s de %s",
+		"Warning: failed to get non-business days: %v": "Aviso: falha ao obter os dias não úteis: %v",
+
+		// cal --heatmap
+		"holiday":                           "feriado",
+		"missing":                           "faltando",
+		"Total logged:   %s over %d day(s)": "Total apontado:  %s em %d dia(s)",
+		"Days missing:   %d (and %d below journey)":   "Dias faltando:   %d (e %d abaixo da jornada)",
+		"Longest streak: %d business day(s), %s → %s": "Maior sequência: %d dia(s) útil(eis), %s → %s",
+		"Longest streak: 0 business days":             "Maior sequência: 0 dias úteis",
+
+		// balance
+		"Hour bank for %s, %s":     "Banco de horas de %s, %s",
+		"Opening balance %s on %s": "Saldo inicial %s em %s",
+		"Opening balance %s":       "Saldo inicial %s",
+		"WEEK":                     "SEMANA",
+		"FROM":                     "DE",
+		"TO":                       "ATÉ",
+		"EXPECTED":                 "PREVISTO",
+		"WORKED":                   "TRABALHADO",
+		"DELTA":                    "DIFERENÇA",
+		"BALANCE":                  "SALDO",
+		"CURRENT BALANCE: %s":      "SALDO ATUAL: %s",
+		"No --from given and the profile has no openingBalances.": "Sem --from e o perfil não tem openingBalances.",
+		"Invalid opening balance date %q: %v":                     "Data de saldo inicial inválida %q: %v",
+		"Invalid range: %s is after %s":                           "Intervalo inválido: %s é depois de %s",
+		"Error getting non-business days: %v":                     "Erro ao obter os dias não úteis: %v",
+
+		// budget
+		"Watching %d ticket(s).": "Acompanhando %d chamado(s).",
+		"No watched tickets.":    "Nenhum chamado acompanhado.",
+		"Watched tickets:":       "Chamados acompanhados:",
+		"No watched tickets. Use --add <ticket> or pass tickets as arguments.": "Nenhum chamado acompanhado. Use --add <chamado> ou passe chamados como argumentos.",
+		"Ticket %s: no history yet":                   "Chamado %s: ainda sem histórico",
+		"approved %s  consumed %s  remaining %s":      "aprovado %s  consumido %s  restante %s",
+		"%8s left":                                    "%8s restantes",
+		"no consumption recorded yet":                 "nenhum consumo registrado ainda",
+		"rate %s/day · budget exhausted":              "ritmo %s/dia · orçamento esgotado",
+		"rate %s/day · exhaustion ~%s (%d days)":      "ritmo %s/dia · esgota em ~%s (%d dias)",
+		"%s left, below the %s threshold":             "%s restantes, abaixo do limite de %s",
+		"Ticket %s: %v":                               "Chamado %s: %v",
+		"no project hour budget":                      "sem orçamento de horas no projeto",
+		"approved hours: %w":                          "horas aprovadas: %w",
+		"consumed hours: %w":                          "horas consumidas: %w",
+		"Warning: failed to write budget history: %v": "Aviso: falha ao gravar o histórico do orçamento: %v",
+		"invalid hours %q":                            "horas inválidas %q",
+
+		// close-check
+		"Closing check for period %04d-%02d (%s)":  "Verificação de fechamento do período %04d-%02d (%s)",
+		"%d problem(s) found.":                     "%d problema(s) encontrado(s).",
+		"Timesheet is ready to close.":             "Apontamentos prontos para o fechamento.",
+		"Business days below journey":              "Dias úteis abaixo da jornada",
+		"Entries on weekends or non-business days": "Apontamentos em fins de semana ou dias não úteis",
+		"Entries missing a required ticket":        "Apontamentos sem o chamado obrigatório",
+		"Duplicate entries":                        "Apontamentos duplicados",
+		"Days above the %s hard cap":               "Dias acima do limite de %s",
+		"Entries on projects no longer assigned":   "Apontamentos em projetos não mais atribuídos",
+		"%s of %s":                                 "%s de %s",
+		"%s on a weekend":                          "%s em fim de semana",
+		"%s on a non-business day":                 "%s em dia não útil",
+		"%s logged":                                "%s apontadas",
+		"#%d duplicates #%d: %s":                   "#%d duplica #%d: %s",
+		"Invalid --month %q: expected YYYY-MM":     "--month inválido %q: use AAAA-MM",
+
+		// list-timesheets
+		"the current period":                  "o período atual",
+		"No timesheets found for %s.":         "Nenhum apontamento encontrado para %s.",
+		"Timesheets for user %s (%d) for %s:": "Apontamentos do usuário %s (%d) para %s:",
+		"TimesheetType":                       "Tipo",
+		"Date":                                "Data",
+		"SalesOrder":                          "Ordem de venda",
+		"SalesOrderLine":                      "Linha",
+		"Warning: failed to write timesheets to cache: %v": "Aviso: falha ao gravar os apontamentos no cache: %v",
+		"Filter error: %v": "Erro no filtro: %v",
+
+		// report
+		"Hours from %s to %s: %s":            "Horas de %s a %s: %s",
+		"No timesheets found in this range.": "Nenhum apontamento encontrado neste intervalo.",
+		"Projects":                           "Projetos",
+		"Tickets":                            "Chamados",
+		"Types":                              "Tipos",
+		"target %s%% (%s)":                   "meta %s%% (%s)",
+		"Failed to encode report: %v":        "Falha ao codificar o relatório: %v",
+		"Failed to write CSV: %v":            "Falha ao gravar o CSV: %v",
+		"Invalid --output %q: expected table|json|csv": "--output inválido %q: use table|json|csv",
+
+		// tickets
+		"Ticket":           "Chamado",
+		"Ticket %s":        "Chamado %s",
+		"Ticket Number":    "Número do chamado",
+		"Priority":         "Prioridade",
+		"Created Date":     "Data de criação",
+		"Logged":           "Apontado",
+		"Last Logged":      "Último apontamento",
+		"Status":           "Status",
+		"Process Type: %s": "Tipo de processo: %s",
+		"Category: %s":     "Categoria: %s",
+		"Created At: %s":   "Criado em: %s",
+		"Changed At: %s":   "Alterado em: %s",
+		"Logged by me: %s in %d entries, last on %s": "Apontado por mim: %s em %d apontamento(s), último em %s",
+		"Created By:":                  "Criado por:",
+		"%s <%s> | Phone: %s":          "%s <%s> | Telefone: %s",
+		"Processor:":                   "Responsável:",
+		"--- Texts ---":                "--- Textos ---",
+		"--- Attachments ---":          "--- Anexos ---",
+		"%s by %s":                     "%s por %s",
+		"Total Approved Project: %s":   "Total aprovado no projeto: %s",
+		"Total Consumed Project: %s":   "Total consumido no projeto: %s",
+		"Total Disponible Project: %s": "Total disponível no projeto: %s",
+		"Every ticket has time logged in the last %d days.": "Todos os chamados têm horas apontadas nos últimos %d dias.",
+		"%d of %d ticket(s) untouched for %d days.":         "%d de %d chamado(s) sem apontamento há %d dias.",
+		"never":                                         "nunca",
+		"no attachment matches %q":                      "nenhum anexo corresponde a %q",
+		"invalid --filter: %w":                          "--filter inválido: %w",
+		"Skipping cache file %s: %v":                    "Ignorando o arquivo de cache %s: %v",
+		"--days must not be negative":                   "--days não pode ser negativo",
+		"Warning: could not read cached timesheets: %v": "Aviso: não foi possível ler os apontamentos do cache: %v",
+
+		// tickets tui
+		"Refreshing…":                   "Atualizando…",
+		"Refreshed %d ticket(s).":       "%d chamado(s) atualizado(s).",
+		"Loading ticket %s…":            "Carregando chamado %s…",
+		"Error loading %s: %v":          "Erro ao carregar %s: %v",
+		"Ticket %s has no attachments.": "O chamado %s não tem anexos.",
+		"Log time on ticket %s":         "Apontar horas no chamado %s",
+		"%d of %d  sort: %s %s":         "%d de %d  ordem: %s %s",
+		"Keys:":                         "Teclas:",
+		"↑↓ move  / filter  s sort  o order  ⏎ details  a attachments  t log time  r refresh  ? help  q quit": "↑↓ mover  / filtrar  s ordenar  o sentido  ⏎ detalhes  a anexos  t apontar  r atualizar  ? ajuda  q sair",
+		"No tickets match.":              "Nenhum chamado corresponde.",
+		"Press Enter to load ticket %s.": "Pressione Enter para carregar o chamado %s.",
+		"Type":                           "Tipo",
+		"hours are required":             "as horas são obrigatórias",
+		"description is required":        "a descrição é obrigatória",
+
+		// tui
+		"Loading %s…":                  "Carregando %s…",
+		"Error getting timesheets: %v": "Erro ao obter apontamentos: %v",
+		"%d pending change(s) not saved: press q again to discard them.": "%d alteração(ões) pendente(s) não salva(s): pressione q de novo para descartá-las.",
+		"Add an entry on %s": "Novo apontamento em %s",
+		"Nothing to save.":   "Nada a salvar.",
+		"Reloading discards %d pending change(s): press r again to confirm.": "Recarregar descarta %d alteração(ões) pendente(s): pressione r de novo para confirmar.",
+		"Reloading…": "Recarregando…",
+		"Reloaded.":  "Recarregado.",
+		"Undelete the entry (d) before editing it.": "Restaure o apontamento (d) antes de editá-lo.",
+		"Edit entry":      "Editar apontamento",
+		"Duplicate entry": "Duplicar apontamento",
+		"%d pending change(s); press s to review and save.": "%d alteração(ões) pendente(s); pressione s para revisar e salvar.",
+		"Saved.":               "Salvo.",
+		"pending: +%d ~%d -%d": "pendentes: +%d ~%d -%d",
+		"arrows move  [ ] month  v view  ⏎ entries  a add  e edit  y duplicate  d delete  s save  ? help  q quit": "setas movem  [ ] mês  v visão  ⏎ apontamentos  a novo  e editar  y duplicar  d excluir  s salvar  ? ajuda  q sair",
+		"of %s":                             "de %s",
+		"No entries. Press a to add one.":   "Sem apontamentos. Pressione a para incluir um.",
+		"new":                               "novo",
+		"Save these changes to Mantis?":     "Salvar estas alterações no Mantis?",
+		"add":                               "incluir",
+		"delete":                            "excluir",
+		"edit":                              "editar",
+		"y save   n/Esc back":               "y salvar   n/Esc voltar",
+		"Invalid --month %d: expected 1-12": "--month inválido %d: use 1-12",
+
+		// alias
+		"Profile '%s' has no aliases. Add one with 'intracli alias add'.": "O perfil '%s' não tem aliases. Crie um com 'intracli alias add'.",
+		"Alias":                     "Alias",
+		"Project":                   "Projeto",
+		"Line":                      "Linha",
+		"Needs Ticket":              "Exige chamado",
+		"Assigned":                  "Atribuído",
+		"Defaults":                  "Padrões",
+		"no":                        "não",
+		"yes":                       "sim",
+		"Invalid project number %q": "Número de projeto inválido %q",
+		"Alias '%s' already exists. Use --force to overwrite it.":          "O alias '%s' já existe. Use --force para sobrescrevê-lo.",
+		"Project %d is not assigned to you. See 'intracli list-projects'.": "O projeto %d não está atribuído a você. Veja 'intracli list-projects'.",
+		"Project %d has several lines (%s); pick one with --line.":         "O projeto %d tem várias linhas (%s); escolha uma com --line.",
+		"Alias '%s' saved for project %d (%s).":                            "Alias '%s' salvo para o projeto %d (%s).",
+		"Alias '%s' not found in profile '%s'.":                            "Alias '%s' não encontrado no perfil '%s'.",
+		"Removed %s.":                                                      "%s removido.",
+		"Alias '%s' already exists.":                                       "O alias '%s' já existe.",
+		"Renamed alias '%s' to '%s'.":                                      "Alias '%s' renomeado para '%s'.",
+		"Nothing to set; see 'intracli alias set --help'.":                 "Nada a definir; veja 'intracli alias set --help'.",
+		"Alias '%s' defaults: %s":                                          "Padrões do alias '%s': %s",
+		"Alias '%s' has no defaults.":                                      "O alias '%s' não tem padrões.",
+		"sales order %d line %d":                                           "ordem de venda %d linha %d",
+		"Every alias is still assigned.":                                   "Todos os aliases continuam atribuídos.",
+		"%d alias(es) would be removed.":                                   "%d alias(es) seriam removidos.",
+		"Remove %d alias(es)?":                                             "Remover %d alias(es)?",
+		"Aborted.":                                                         "Cancelado.",
+		"Removed %d alias(es).":                                            "%d alias(es) removido(s).",
+		"  %s: needsTicket %t → %t":                                        "  %s: needsTicket %t → %t",
+		"  added %s for %d (%s)":                                           "  incluído %s para %d (%s)",
+		"Aliases are up to date.":                                          "Os aliases estão atualizados.",
+		"%d change(s) not saved (--dry-run).":                              "%d alteração(ões) não salva(s) (--dry-run).",
+		"Saved %d change(s) to profile '%s'.":                              "%d alteração(ões) salva(s) no perfil '%s'.",
+		"Profile '%s' not found in config":                                 "Perfil '%s' não encontrado na configuração",
+		"Failed to save config: %v":                                        "Falha ao salvar a configuração: %v",
+		"Employee code not found. Please run 'intracli search-employee' to update your profile.": "Código de funcionário não encontrado. Execute 'intracli search-employee' para atualizar o seu perfil.",
+		"Error getting projects: %v": "Erro ao obter os projetos: %v",
+
+		// appoint
+		"Profile '%s' not found in configuration. Please check your config.yaml.":               "Perfil '%s' não encontrado na configuração. Verifique o seu config.yaml.",
+		"Missing required flags: --description":                                                 "Flag obrigatória ausente: --description",
+		"Missing required flags: --hours":                                                       "Flag obrigatória ausente: --hours",
+		"Missing required flags: --project-alias":                                               "Flag obrigatória ausente: --project-alias",
+		"Attempting to create appointment:\n%+v":                                                "Criando apontamento:\n%+v",
+		"Project alias '%s' not found in your default profile.":                                 "Alias de projeto '%s' não encontrado no seu perfil padrão.",
+		"Error: Project '%s' requires a ticket number. Please provide one using --ticket (-t).": "Erro: o projeto '%s' exige um número de chamado. Informe-o com --ticket (-t).",
+		"Invalid hours format: %v":                                                              "Formato de horas inválido: %v",
+		"Invalid date format. Please use YYYY-MM-DD. Error: %v":                                 "Formato de data inválido. Use AAAA-MM-DD. Erro: %v",
+		"invalid duration format: %s. Expected format like '8h' or '1d 2h'":                     "formato de duração inválido: %s. Use algo como '8h' ou '1d 2h'",
+		"invalid number in duration string: %s":                                                 "número inválido na duração: %s",
+		"unknown unit '%s' in duration string":                                                  "unidade desconhecida '%s' na duração",
+		"Sending timesheet":                                                                     "Enviando apontamento",
+		"Successfully created timesheet for %s with %.2f hours":                                 "Apontamento criado para %s com %.2f horas",
+		"Mantis error when creating timesheet for %s: %s":                                       "Erro do Mantis ao criar o apontamento para %s: %s",
+		"Failed to write template to file: %v":                                                  "Falha ao gravar o modelo no arquivo: %v",
+		"Error opening editor: %v":                                                              "Erro ao abrir o editor: %v",
+		"Failed to read edited file: %v":                                                        "Falha ao ler o arquivo editado: %v",
+		"Skipping block: unknown project-alias '%s'":                                            "Ignorando bloco: project-alias desconhecido '%s'",
+		"Invalid date, using today instead: %v":                                                 "Data inválida, usando hoje: %v",
+		"Skipping block: project '%s' requires ticket":                                          "Ignorando bloco: o projeto '%s' exige chamado",
+		"Skipping block due to invalid hours: %v":                                               "Ignorando bloco com horas inválidas: %v",
+		"Creating appointment: %+v":                                                             "Criando apontamento: %+v",
+
+		// attachments
+		"invalid --match %q: %w":     "--match inválido %q: %w",
+		"No attachments.":            "Sem anexos.",
+		"  by %s":                    "  por %s",
+		"Downloading attachment: %s": "Baixando anexo: %s",
+		"Error trying to download attachment [%s]: %v": "Erro ao baixar o anexo [%s]: %v",
+		"Error saving attachment [%s]: %v":             "Erro ao salvar o anexo [%s]: %v",
+		"Already present: %s":                          "Já existe: %s",
+		"Saved: %s":                                    "Salvo: %s",
+		"Error extracting [%s]: %v":                    "Erro ao extrair [%s]: %v",
+		"Extracted %d file(s) into %s":                 "%d arquivo(s) extraído(s) em %s",
+		"decoding: %w":                                 "decodificando: %w",
+		"archive expands beyond %d bytes":              "o arquivo compactado passa de %d bytes ao extrair",
+		"Skipping unsafe archive entry %q":             "Ignorando a entrada insegura %q do arquivo compactado",
+
+		// cal --from/--to
+		"--to needs --from":             "--to exige --from",
+		"invalid range: %s is after %s": "intervalo inválido: %s é depois de %s",
+		"error getting timesheets: %w":  "erro ao obter apontamentos: %w",
+
+		// clean
+		"Invalid Clean Type": "Tipo de limpeza inválido",
+		"missing clean type": "tipo de limpeza ausente",
+
+		// profiles and login
+		"Fatal error during config initialization: %v": "Erro fatal ao inicializar a configuração: %v",
+		"profile '%s': %w":                                                            "perfil '%s': %w",
+		"authentication failed: %w":                                                   "falha na autenticação: %w",
+		"failed to get employee information for '%d': %w":                             "falha ao obter os dados do funcionário '%d': %w",
+		"credentialRef %s: set %s_USERNAME and %s_PASSWORD":                           "credentialRef %s: defina %s_USERNAME e %s_PASSWORD",
+		"credentialRef command failed: %w":                                            "o comando do credentialRef falhou: %w",
+		"credentialRef command must print the username and the password on two lines": "o comando do credentialRef deve imprimir o usuário e a senha em duas linhas",
+		"invalid credentialRef %q: expected env:PREFIX or cmd:COMMAND":                "credentialRef inválido %q: use env:PREFIXO ou cmd:COMANDO",
+		"Mantis username: ":                                                           "Usuário do Mantis: ",
+		"Mantis password: ":                                                           "Senha do Mantis: ",
+		"Set the following environment variables:":                                    "Defina as seguintes variáveis de ambiente:",
+		"In your shellrc.":                                                            "No seu shellrc.",
+		"saving role: %w":                                                             "salvando o papel: %w",
+		"Role set to: %s (ID: %s)":                                                    "Papel definido: %s (ID: %s)",
+		"Error loading config for completion: %v":                                     "Erro ao carregar a configuração para o autocompletar: %v",
+		"Default profile '%s' not found for completion.":                              "Perfil padrão '%s' não encontrado para o autocompletar.",
+		"profile '%s' not found":                                                      "perfil '%s' não encontrado",
+		"Error loading cached tickets: %v":                                            "Erro ao carregar os chamados do cache: %v",
+		"loading config: %w":                                                          "carregando a configuração: %w",
+		"Error: %v":                                                                   "Erro: %v",
+
+		// contracts
+		"Error getting contracts: %v":  "Erro ao obter os contratos: %v",
+		"Failed to write to cache: %v": "Falha ao gravar no cache: %v",
+
+		// daemon
+		"Polling tickets every %s. Press Ctrl+C to stop.": "Consultando os chamados a cada %s. Pressione Ctrl+C para parar.",
+		"Error polling tickets: %v":                       "Erro ao consultar os chamados: %v",
+		"%s  recorded a baseline of %d ticket(s)":         "%s  linha de base registrada com %d chamado(s)",
+
+		// date-summary
+		"Error loading config: %v":            "Erro ao carregar a configuração: %v",
+		"Profile '%s' not found":              "Perfil '%s' não encontrado",
+		"Error fetching timesheets: %v":       "Erro ao buscar os apontamentos: %v",
+		"DATE":                                "DATA",
+		"HOURS":                               "HORAS",
+		"STATUS":                              "STATUS",
+		"PROJECT":                             "PROJETO",
+		"USER":                                "USUÁRIO",
+		"MONTH":                               "MÊS",
+		"WORKLOAD":                            "CARGA",
+		"WEEKLY TOTALS:":                      "TOTAIS SEMANAIS:",
+		"  %s: %s hours":                      "  %s: %s horas",
+		"AVERAGE DAILY HOURS: %s (target %s)": "MÉDIA DE HORAS POR DIA: %s (meta %s)",
+		"MONTHLY TOTALS:":                     "TOTAIS MENSAIS:",
+		"MISSING":                             "FALTANDO",
+		"OVERTIME":                            "HORA EXTRA",
+		"OK":                                  "OK",
+
+		// delete
+		"Error: no timesheet ID or filter provided. Use --id (-i) or --filter.": "Erro: nenhum ID de apontamento ou filtro informado. Use --id (-i) ou --filter.",
+		"Failed to fetch timesheets: %v":                                        "Falha ao buscar os apontamentos: %v",
+		"Attempting to delete timesheet: %d":                                    "Excluindo o apontamento: %d",
+		"Error deleting timesheet %d: %v":                                       "Erro ao excluir o apontamento %d: %v",
+
+		// edit
+		"Failed to fetch timesheet %d: %v":           "Falha ao buscar o apontamento %d: %v",
+		"Must provide either --id or --filter":       "Informe --id ou --filter",
+		"No timesheets matched the criteria.":        "Nenhum apontamento atende aos critérios.",
+		"Invalid date format: %v":                    "Formato de data inválido: %v",
+		"Unknown project alias '%s'":                 "Alias de projeto desconhecido '%s'",
+		"Project '%s' requires a ticket":             "O projeto '%s' exige chamado",
+		"Unknown timesheet type '%s'":                "Tipo de apontamento desconhecido '%s'",
+		"Failed to delete timesheet %d: %v":          "Falha ao excluir o apontamento %d: %v",
+		"Recreating timesheet: %+v":                  "Recriando o apontamento: %+v",
+		"Failed to write temporary file: %v":         "Falha ao gravar o arquivo temporário: %v",
+		"No changes detected. Aborting edit.":        "Nenhuma alteração detectada. Edição cancelada.",
+		"Skipping ID %d: unknown project-alias '%s'": "Ignorando o ID %d: project-alias desconhecido '%s'",
+		"Skipping ID %d: invalid hours: %v":          "Ignorando o ID %d: horas inválidas: %v",
+		"Updating timesheet %d...":                   "Atualizando o apontamento %d...",
+
+		// export
+		"Invalid --day-start %q: expected HH:MM":     "--day-start inválido %q: use HH:MM",
+		"Failed to write %s: %v":                     "Falha ao gravar %s: %v",
+		"Wrote %s":                                   "%s gravado",
+		"Error building feed: %v":                    "Erro ao montar o feed: %v",
+		"Serving the feed on http://%s/intracli.ics": "Servindo o feed em http://%s/intracli.ics",
+		"Press Ctrl+C to stop.":                      "Pressione Ctrl+C para parar.",
+
+		// period fetching
+		"Warning: failed to write cache (%s): %v": "Aviso: falha ao gravar o cache (%s): %v",
+		"Fetching %s %d/%d…":                      "Buscando %s %d/%d…",
+		"timesheets":                              "apontamentos",
+		"non-business days":                       "dias não úteis",
+
+		// filters
+		"No saved filters.":        "Nenhum filtro salvo.",
+		"Saved timesheet filters:": "Filtros de apontamentos salvos:",
+		"Filter '%s' not found.":   "Filtro '%s' não encontrado.",
+		"Filter '%s' deleted.":     "Filtro '%s' excluído.",
+		"No action taken. Use --save <name> [query], --list, or --delete <name>.": "Nada feito. Use --save <nome> [consulta], --list ou --delete <nome>.",
+		"Filter '%s' saved: %q":     "Filtro '%s' salvo: %q",
+		"saved filter %q not found": "filtro salvo %q não encontrado",
+		"No saved day filters.":     "Nenhum filtro de dias salvo.",
+		"Saved day filters:":        "Filtros de dias salvos:",
+		"No action taken. Use --save <n> [query], --list, or --delete <n>.": "Nada feito. Use --save <n> [consulta], --list ou --delete <n>.",
+		"No saved ticket filters.": "Nenhum filtro de chamados salvo.",
+		"Saved ticket filters:":    "Filtros de chamados salvos:",
+
+		// list-projects
+		"When using --alias, you must also provide --project-number.": "Com --alias, informe também --project-number.",
+		"Project number %d not found in current project list":         "Projeto %d não encontrado na lista atual de projetos",
+		"No projects found for the current employee.":                 "Nenhum projeto encontrado para o funcionário atual.",
+		"Projects for user %s (%d):":                                  "Projetos do usuário %s (%d):",
+		"Project Title":                                               "Título do projeto",
+		"Project Number":                                              "Número do projeto",
+		"Project Item":                                                "Item do projeto",
+
+		// profile
+		"No profiles. Create one with search-employee --create-profile.": "Nenhum perfil. Crie um com search-employee --create-profile.",
+		"user %d, %s/day, %d alias(es)":                                  "usuário %d, %s/dia, %d alias(es)",
+		", role %d":                                                      ", papel %d",
+		"Profile '%s' not found.":                                        "Perfil '%s' não encontrado.",
+		"Error saving config: %v":                                        "Erro ao salvar a configuração: %v",
+		"Default profile is now '%s'.":                                   "O perfil padrão agora é '%s'.",
+		"Profile '%s' already exists.":                                   "O perfil '%s' já existe.",
+		"Copied profile '%s' to '%s'.":                                   "Perfil '%s' copiado para '%s'.",
+		"Renamed profile '%s' to '%s'.":                                  "Perfil '%s' renomeado para '%s'.",
+		"'%s' is the default profile. Switch with 'intracli profile use' first.": "'%s' é o perfil padrão. Troque antes com 'intracli profile use'.",
+		"Delete profile '%s'?":                                 "Excluir o perfil '%s'?",
+		"Deleted profile '%s'.":                                "Perfil '%s' excluído.",
+		"Invalid --strategy %q: expected ask, keep or replace": "--strategy inválido %q: use ask, keep ou replace",
+		"Invalid profile file %s: %v":                          "Arquivo de perfil inválido %s: %v",
+		"The file has no profile name; pass --name.":           "O arquivo não tem nome de perfil; informe --name.",
+		"Merged '%s' into profile '%s'.":                       "'%s' mesclado no perfil '%s'.",
+		"Imported profile '%s'.":                               "Perfil '%s' importado.",
+		"The file sets %s: %s":                                 "O arquivo define %s: %s",
+		"Save this %s?":                                        "Salvar este %s?",
+		"Leaving %s unset.":                                    "%s fica sem valor.",
+		"Keeping %s %s.":                                       "Mantendo %s %s.",
+		"Warning: not checking the employee, the profile uses another Mantis instance (%s)": "Aviso: o funcionário não foi verificado, o perfil usa outra instância do Mantis (%s)",
+		"the profile has neither userID nor employeeName":                                   "o perfil não tem userID nem employeeName",
+		"employee of the profile not found in Mantis: %w":                                   "funcionário do perfil não encontrado no Mantis: %w",
+		"Warning: %s is %q in the file but %q in Mantis":                                    "Aviso: %s é %q no arquivo mas %q no Mantis",
+		"Warning: employeeCode is %d in the file but %d in Mantis":                          "Aviso: employeeCode é %d no arquivo mas %d no Mantis",
+		"alias '%s' conflicts and stdin is not a terminal; pass --strategy keep or replace": "o alias '%s' conflita e a entrada padrão não é um terminal; informe --strategy keep ou replace",
+		"Alias '%s' differs:":      "O alias '%s' difere:",
+		"  existing: %s":           "  atual:   %s",
+		"  file:     %s":           "  arquivo: %s",
+		"Replace with the file's?": "Substituir pelo do arquivo?",
+		", needs ticket":           ", exige chamado",
+		", target %s%%":            ", meta %s%%",
+		", defaults %s":            ", padrões %s",
+		"%s [y/N]: ":               "%s [s/N]: ",
+
+		// ranges
+		"invalid date %q: expected YYYY-MM-DD or a relative token": "data inválida %q: use AAAA-MM-DD ou um termo relativo",
+
+		// report tickets
+		"Invalid --output %q: expected table|json": "--output inválido %q: use table|json",
+		"Error getting tickets: %v":                "Erro ao obter os chamados: %v",
+		"invalid --filter: %v":                     "--filter inválido: %v",
+		"Warning: failed to write to cache: %v":    "Aviso: falha ao gravar no cache: %v",
+		"%d ticket(s)":                             "%d chamado(s)",
+		"No tickets found.":                        "Nenhum chamado encontrado.",
+		"  median age %s  p90 %s":                  "  idade mediana %s  p90 %s",
+		"%s breached  %s at risk":                  "%s estourado(s)  %s em risco",
+		"Age by status":                            "Idade por status",
+		"Age by priority":                          "Idade por prioridade",
+		"PRIORITY":                                 "PRIORIDADE",
+		"TOTAL":                                    "TOTAL",
+		"MEDIAN":                                   "MEDIANA",
+		"No earlier snapshot to compare with yet.": "Ainda não há retrato anterior para comparar.",
+		"Since %s (%s):":                           "Desde %s (%s):",
+		"tickets":                                  "chamados",
+		"breached":                                 "estourados",
+		"at risk":                                  "em risco",
+		"median":                                   "mediana",
+		"breached (0%)":                            "estourado (0%)",
+		"at risk (<50%)":                           "em risco (<50%)",
+		"warning (50-79%)":                         "atenção (50-79%)",
+		"ok (80%+)":                                "ok (80%+)",
+
+		// roleID
+		"Error getting user roles: %v":                            "Erro ao obter os papéis do usuário: %v",
+		"Error getting user role: %v":                             "Erro ao obter o papel do usuário: %v",
+		"RoleID '%s' (%d) saved in profile '%s'":                  "RoleID '%s' (%d) salvo no perfil '%s'",
+		"failed to retrieve user roles: %w":                       "falha ao obter os papéis do usuário: %w",
+		"no roles available for user %d":                          "nenhum papel disponível para o usuário %d",
+		"%d. %s (ID: %d)":                                         "%d. %s (ID: %d)",
+		"Enter the number of your chosen role: ":                  "Digite o número do papel escolhido: ",
+		"error reading choice: %w":                                "erro ao ler a escolha: %w",
+		"Invalid choice. Please enter a number between 1 and %d.": "Escolha inválida. Digite um número entre 1 e %d.",
+
+		// search-employee
+		"Error: Employee name to search for is required. Use --name (-n).":                         "Erro: informe o nome do funcionário a buscar com --name (-n).",
+		"No exact match found for '%s'. Attempting partial match or broader search logic...":       "Nenhum resultado exato para '%s'. Tentando uma busca parcial...",
+		"No active employee found matching '%s'. Please try a different name or part of the name.": "Nenhum funcionário ativo encontrado para '%s'. Tente outro nome ou parte do nome.",
+		"Error searching for employee '%s': %v":                                                    "Erro ao buscar o funcionário '%s': %v",
+		"Error getting employees: %v":                                                              "Erro ao obter os funcionários: %v",
+		"Profile '%s' already exists. Choose a different name.":                                    "O perfil '%s' já existe. Escolha outro nome.",
+		"Do you wish to update it?":                                                                "Deseja atualizá-lo?",
+		"Updating profile...":                                                                      "Atualizando o perfil...",
+		"Profile '%s' updated successfully.":                                                       "Perfil '%s' atualizado.",
+		"Aborting...":                                                                              "Cancelando...",
+		"Profile '%s' created successfully.":                                                       "Perfil '%s' criado.",
+		"Found employee:":                                                                          "Funcionário encontrado:",
+		"  Full Name: %s":                                                                          "  Nome completo: %s",
+		"  Employee Code: %d":                                                                      "  Código do funcionário: %d",
+		"  User ID: %d":                                                                            "  ID do usuário: %d",
+		"  SUser ID: %s":                                                                           "  ID SUser: %s",
+		"  Email: %s":                                                                              "  E-mail: %s",
+		"  Daily Journey: %.2f":                                                                    "  Jornada diária: %.2f",
+
+		// tickets export
+		"Error: a ticket is required. Use --ticket (-t).": "Erro: informe um chamado com --ticket (-t).",
+		"Invalid --format %q: expected md|html|json":      "--format inválido %q: use md|html|json",
+		"Error getting ticket %s: %v":                     "Erro ao obter o chamado %s: %v",
+		"Failed to create %s: %v":                         "Falha ao criar %s: %v",
+		"Failed to write export: %v":                      "Falha ao gravar a exportação: %v",
+		"Warning: failed to create %s: %v":                "Aviso: falha ao criar %s: %v",
+		"Warning: failed to download %s: %v":              "Aviso: falha ao baixar %s: %v",
+		"Warning: failed to save %s: %v":                  "Aviso: falha ao salvar %s: %v",
+		"Field":                                           "Campo",
+		"Value":                                           "Valor",
+		"Process type":                                    "Tipo de processo",
+		"Category":                                        "Categoria",
+		"Created":                                         "Criado em",
+		"Changed":                                         "Alterado em",
+		"Created by":                                      "Criado por",
+		"Processor":                                       "Responsável",
+		"Project hours":                                   "Horas do projeto",
+		"%s consumed of %s approved":                      "%s consumidas de %s aprovadas",
+		"Thread":                                          "Histórico",
+		"Attachments":                                     "Anexos",
+		", by %s":                                         ", por %s",
+		" on %s":                                          " em %s",
+
+		// tickets search
+		"Error updating search index: %v":                                                        "Erro ao atualizar o índice de busca: %v",
+		"Indexed %d new or changed ticket(s).":                                                   "%d chamado(s) novo(s) ou alterado(s) indexado(s).",
+		"No tickets cached yet. Run 'intracli tickets' or 'intracli tickets -t <ticket>' first.": "Nenhum chamado no cache ainda. Execute antes 'intracli tickets' ou 'intracli tickets -t <chamado>'.",
+		"No tickets match %q.":                                                                   "Nenhum chamado corresponde a %q.",
+		"%d more result(s); use --limit to see them.":                                            "Mais %d resultado(s); use --limit para vê-los.",
+
+		// tickets watch
+		"Error watching tickets: %v":                 "Erro ao acompanhar os chamados: %v",
+		"No previous snapshot: recorded a baseline.": "Sem retrato anterior: linha de base registrada.",
+		"No changes.": "Sem alterações.",
+		"Warning: failed to write ticket snapshot: %v":  "Aviso: falha ao gravar o retrato dos chamados: %v",
+		"Warning: failed to get texts of ticket %s: %v": "Aviso: falha ao obter os textos do chamado %s: %v",
+		"Warning: notification for ticket %s: %v":       "Aviso: notificação do chamado %s: %v",
+		"Warning: failed to write text snapshot: %v":    "Aviso: falha ao gravar o retrato dos textos: %v",
+		"%s %s new  %s  SLA %s  %s":                     "%s %s novo  %s  SLA %s  %s",
+		"%s %s left the report  %s":                     "%s %s saiu do relatório  %s",
+		"~ %s status %s → %s  %s":                       "~ %s status %s → %s  %s",
+		"~ %s priority %s → %s  %s":                     "~ %s prioridade %s → %s  %s",
+		"%s %s SLA %s → %s  %s":                         "%s %s SLA %s → %s  %s",
+		"%s %s new comment by %s %s":                    "%s %s novo comentário de %s %s",
+
+		// undo
+		"Failed to read to delete cache: %v":  "Falha ao ler o cache de exclusões: %v",
+		"No to delete information available.": "Nenhuma exclusão registrada.",
+		"Deletion completed.":                 "Exclusão concluída.",
+		"Failed to read undo cache: %v":       "Falha ao ler o cache de desfazer: %v",
+		"No undo information available.":      "Nada a desfazer.",
+		"Restoring timesheet: %+v":            "Restaurando o apontamento: %+v",
+		"Undo completed.":                     "Desfazer concluído.",
+		"Removing undo cache file: %s":        "Removendo o arquivo de cache de desfazer: %s",
+	},
+}
+
+var enUS = Locale{
+	Tag: "en_US",
+	Months: [12]string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	},
+	MonthsShort: [12]string{
+		"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+		"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+	},
+	Weekdays: [7]string{
+		"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+	},
+	WeekdaysShort: [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+	DayMonth:      "01/02",
+	ShortDate:     "%[2]s %[1]d %[3]d",
+	LongDate:      "%[2]s %[1]d, %[3]d",
+	Decimal:       ".",
+}
@@ -0,0 +1,189 @@
+// Package notify delivers ticket alerts to pluggable sinks: the terminal
+// bell, desktop notifications through notify-send, a shell command hook or a
+// webhook.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/config"
+)
+
+const (
+	SinkBell    = "bell"
+	SinkDesktop = "desktop"
+	SinkCommand = "command"
+	SinkWebhook = "webhook"
+)
+
+// SinkNames lists the sink names accepted in the config.
+var SinkNames = []string{SinkBell, SinkDesktop, SinkCommand, SinkWebhook}
+
+type Kind string
+
+const (
+	KindSLA    Kind = "sla"
+	KindStatus Kind = "status"
+)
+
+// Notification is one alert about a ticket.
+type Notification struct {
+	Kind   Kind      `json:"kind"`
+	Ticket string    `json:"ticket"`
+	Title  string    `json:"title"`
+	Body   string    `json:"body"`
+	Urgent bool      `json:"urgent"`
+	Time   time.Time `json:"time"`
+}
+
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, n Notification) error
+}
+
+// FromConfig builds the configured sinks. With no sinks configured it falls
+// back to the terminal bell.
+func FromConfig(cfg config.NotifyConfig) ([]Sink, error) {
+	names := cfg.Sinks
+	if len(names) == 0 {
+		names = []string{SinkBell}
+	}
+
+	sinks := make([]Sink, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case SinkBell:
+			sinks = append(sinks, Bell{W: os.Stderr})
+		case SinkDesktop:
+			sinks = append(sinks, Desktop{})
+		case SinkCommand:
+			if cfg.Command == "" {
+				return nil, fmt.Errorf("sink %q needs notifications.command", name)
+			}
+			sinks = append(sinks, Command{Command: cfg.Command})
+		case SinkWebhook:
+			if cfg.Webhook == "" {
+				return nil, fmt.Errorf("sink %q needs notifications.webhook", name)
+			}
+			sinks = append(sinks, Webhook{URL: cfg.Webhook})
+		default:
+			return nil, fmt.Errorf("unknown notification sink %q (expected one of %v)", name, SinkNames)
+		}
+	}
+	return sinks, nil
+}
+
+// Send delivers n to every sink, returning the joined delivery errors.
+func Send(ctx context.Context, sinks []Sink, n Notification) error {
+	var errs []error
+	for _, s := range sinks {
+		if err := s.Send(ctx, n); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Bell rings the terminal bell. The commands print the notification
+// themselves.
+type Bell struct {
+	W io.Writer
+}
+
+func (Bell) Name() string { return SinkBell }
+
+func (b Bell) Send(ctx context.Context, n Notification) error {
+	_, err := io.WriteString(b.W, "\a")
+	return err
+}
+
+// Desktop shows a desktop notification through notify-send.
+type Desktop struct{}
+
+func (Desktop) Name() string { return SinkDesktop }
+
+func (Desktop) Send(ctx context.Context, n Notification) error {
+	urgency := "normal"
+	if n.Urgent {
+		urgency = "critical"
+	}
+	out, err := exec.CommandContext(ctx,
+		"notify-send", "--app-name=intracli", "--urgency="+urgency, n.Title, n.Body,
+	).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("notify-send: %w: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// Command runs a shell command per notification. The notification is passed
+// as JSON on stdin and as INTRACLI_* environment variables.
+type Command struct {
+	Command string
+}
+
+func (Command) Name() string { return SinkCommand }
+
+func (c Command) Send(ctx context.Context, n Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.Command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"INTRACLI_KIND="+string(n.Kind),
+		"INTRACLI_TICKET="+n.Ticket,
+		"INTRACLI_TITLE="+n.Title,
+		"INTRACLI_BODY="+n.Body,
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// Webhook POSTs the notification as JSON.
+type Webhook struct {
+	URL string
+}
+
+func (Webhook) Name() string { return SinkWebhook }
+
+func (w Webhook) Send(ctx context.Context, n Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
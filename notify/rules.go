@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/utils"
+	"github.com/Salvadego/mantis/mantis"
+)
+
+// DefaultSLAThreshold matches the red SLA color of the tickets listing.
+const DefaultSLAThreshold = 50
+
+// Rules decide which ticket changes deserve a notification.
+type Rules struct {
+	// SLAThresholds notify once when a ticket's SLA drops below each percent.
+	SLAThresholds []int
+	// Statuses notify when a ticket changes to one of them.
+	Statuses []string
+	// SUserID restricts notifications to tickets processed by this user.
+	SUserID string
+}
+
+func RulesFromConfig(cfg config.NotifyConfig, suserID string) Rules {
+	thresholds := cfg.SLAThresholds
+	if len(thresholds) == 0 {
+		thresholds = []int{DefaultSLAThreshold}
+	}
+	sorted := slices.Clone(thresholds)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	return Rules{SLAThresholds: sorted, Statuses: cfg.Statuses, SUserID: suserID}
+}
+
+// Evaluate compares two report snapshots. Tickets new to curr are compared
+// against a full SLA and no status, so a ticket that shows up already
+// breached notifies too.
+func (r Rules) Evaluate(prev, curr []mantis.TicketResponse) []Notification {
+	before := make(map[string]mantis.TicketResponse, len(prev))
+	for _, t := range prev {
+		before[t.TicketNumber] = t
+	}
+
+	now := time.Now()
+	var out []Notification
+	for _, t := range curr {
+		if !r.assigned(t) {
+			continue
+		}
+
+		old, ok := before[t.TicketNumber]
+		oldSLA := 100
+		if ok {
+			oldSLA = utils.ParsePercent(old.PercSLA)
+		}
+		newSLA := utils.ParsePercent(t.PercSLA)
+
+		// Only the lowest threshold crossed is reported.
+		for i := len(r.SLAThresholds) - 1; i >= 0; i-- {
+			th := r.SLAThresholds[i]
+			if oldSLA >= th && newSLA < th {
+				out = append(out, Notification{
+					Kind:   KindSLA,
+					Ticket: t.TicketNumber,
+					Title:  fmt.Sprintf("Ticket %s SLA at %s", t.TicketNumber, t.PercSLA),
+					Body:   fmt.Sprintf("Below %d%%: %s", th, t.Description),
+					Urgent: newSLA <= 0,
+					Time:   now,
+				})
+				break
+			}
+		}
+
+		if t.Status != old.Status && r.watchesStatus(t.Status) {
+			out = append(out, Notification{
+				Kind:   KindStatus,
+				Ticket: t.TicketNumber,
+				Title:  fmt.Sprintf("Ticket %s is %s", t.TicketNumber, t.Status),
+				Body:   t.Description,
+				Time:   now,
+			})
+		}
+	}
+	return out
+}
+
+func (r Rules) watchesStatus(status string) bool {
+	for _, s := range r.Statuses {
+		if strings.EqualFold(strings.TrimSpace(s), strings.TrimSpace(status)) {
+			return true
+		}
+	}
+	return false
+}
+
+// assigned reports whether the ticket is processed by the rules' user.
+// Tickets without processor IDs are kept, since the report is already
+// filtered by user.
+func (r Rules) assigned(t mantis.TicketResponse) bool {
+	if r.SUserID == "" {
+		return true
+	}
+	ids := []string{t.ProcessorID1, t.ProcessorID2, t.ProcessorID3, t.ProcessorID4, t.ProcessorID5}
+	empty := true
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		empty = false
+		if strings.EqualFold(id, r.SUserID) {
+			return true
+		}
+	}
+	return empty
+}
@@ -0,0 +1,292 @@
+// Package search is a small full-text index over tickets: their report
+// descriptions and support texts. It is kept in the cache directory and
+// updated incrementally as tickets are fetched.
+package search
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Field weights: a term in the description says more about a ticket than
+// one buried in a long thread.
+const (
+	descriptionWeight = 2.0
+	textWeight        = 1.0
+
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Doc is one indexed ticket. Texts are plain text, oldest first.
+type Doc struct {
+	Ticket      string    `json:"ticket"`
+	Status      string    `json:"status"`
+	ContractID  string    `json:"contractID"`
+	Contract    string    `json:"contract"`
+	Created     time.Time `json:"created"`
+	Description string    `json:"description"`
+	Texts       []string  `json:"texts"`
+	Fingerprint string    `json:"fingerprint"`
+	// Length is the weighted number of terms, for ranking.
+	Length float64 `json:"length"`
+}
+
+// Posting records how often a term occurs in a ticket, per field.
+type Posting struct {
+	Ticket      string `json:"ticket"`
+	Description int    `json:"description,omitempty"`
+	Texts       int    `json:"texts,omitempty"`
+}
+
+func (p Posting) weight() float64 {
+	return float64(p.Description)*descriptionWeight + float64(p.Texts)*textWeight
+}
+
+// Entry is the postings list of one term, as persisted.
+type Entry struct {
+	Term     string    `json:"term"`
+	Postings []Posting `json:"postings"`
+}
+
+// Index maps terms to the tickets containing them.
+type Index struct {
+	docs     map[string]Doc
+	postings map[string]map[string]Posting
+}
+
+// NewIndex restores an index from its persisted docs and entries.
+func NewIndex(docs []Doc, entries []Entry) *Index {
+	idx := &Index{
+		docs:     make(map[string]Doc, len(docs)),
+		postings: make(map[string]map[string]Posting, len(entries)),
+	}
+	for _, d := range docs {
+		idx.docs[d.Ticket] = d
+	}
+	for _, e := range entries {
+		m := make(map[string]Posting, len(e.Postings))
+		for _, p := range e.Postings {
+			if _, ok := idx.docs[p.Ticket]; ok {
+				m[p.Ticket] = p
+			}
+		}
+		if len(m) > 0 {
+			idx.postings[e.Term] = m
+		}
+	}
+	return idx
+}
+
+func (idx *Index) Len() int {
+	return len(idx.docs)
+}
+
+func (idx *Index) Doc(ticket string) (Doc, bool) {
+	d, ok := idx.docs[ticket]
+	return d, ok
+}
+
+// Fingerprint identifies the indexed content of a doc.
+func Fingerprint(d Doc) string {
+	h := sha1.New()
+	for _, s := range append([]string{d.Status, d.ContractID, d.Contract, d.Description}, d.Texts...) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Upsert indexes d, replacing an older version of the same ticket. It
+// returns false when the indexed content did not change.
+func (idx *Index) Upsert(d Doc) bool {
+	d.Fingerprint = Fingerprint(d)
+	if old, ok := idx.docs[d.Ticket]; ok {
+		if old.Fingerprint == d.Fingerprint {
+			return false
+		}
+		idx.remove(d.Ticket)
+	}
+
+	counts := map[string]*Posting{}
+	posting := func(term string) *Posting {
+		p := counts[term]
+		if p == nil {
+			p = &Posting{Ticket: d.Ticket}
+			counts[term] = p
+		}
+		return p
+	}
+	for _, term := range Tokenize(d.Description) {
+		posting(term).Description++
+	}
+	for _, text := range d.Texts {
+		for _, term := range Tokenize(text) {
+			posting(term).Texts++
+		}
+	}
+
+	d.Length = 0
+	for term, p := range counts {
+		d.Length += p.weight()
+		m := idx.postings[term]
+		if m == nil {
+			m = map[string]Posting{}
+			idx.postings[term] = m
+		}
+		m[d.Ticket] = *p
+	}
+	idx.docs[d.Ticket] = d
+	return true
+}
+
+func (idx *Index) remove(ticket string) {
+	for term, m := range idx.postings {
+		delete(m, ticket)
+		if len(m) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	delete(idx.docs, ticket)
+}
+
+// Docs returns the indexed docs sorted by ticket, for persisting.
+func (idx *Index) Docs() []Doc {
+	out := make([]Doc, 0, len(idx.docs))
+	for _, d := range idx.docs {
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Ticket < out[j].Ticket })
+	return out
+}
+
+// Entries returns the postings sorted by term, for persisting.
+func (idx *Index) Entries() []Entry {
+	out := make([]Entry, 0, len(idx.postings))
+	for term, m := range idx.postings {
+		e := Entry{Term: term, Postings: make([]Posting, 0, len(m))}
+		for _, p := range m {
+			e.Postings = append(e.Postings, p)
+		}
+		sort.Slice(e.Postings, func(i, j int) bool { return e.Postings[i].Ticket < e.Postings[j].Ticket })
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Term < out[j].Term })
+	return out
+}
+
+// Filter narrows search results. Zero values match everything.
+type Filter struct {
+	// Status and Contract match case-insensitive substrings; Contract is
+	// checked against the contract ID and title.
+	Status   string
+	Contract string
+	From, To time.Time
+}
+
+func (f Filter) match(d Doc) bool {
+	if f.Status != "" && !containsFold(d.Status, f.Status) {
+		return false
+	}
+	if f.Contract != "" && !containsFold(d.ContractID, f.Contract) && !containsFold(d.Contract, f.Contract) {
+		return false
+	}
+	if !f.From.IsZero() && d.Created.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !d.Created.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+type Result struct {
+	Doc   Doc
+	Score float64
+	// Terms are the normalized query terms, for highlighting.
+	Terms []string
+}
+
+// Search ranks the tickets containing every query term with BM25 over the
+// weighted fields. The last term also matches as a prefix, so results show
+// up while a word is still being typed.
+func (idx *Index) Search(query string, f Filter) []Result {
+	terms := Tokenize(query)
+	if len(terms) == 0 || len(idx.docs) == 0 {
+		return nil
+	}
+
+	avgLen := 0.0
+	for _, d := range idx.docs {
+		avgLen += d.Length
+	}
+	avgLen /= float64(len(idx.docs))
+	if avgLen == 0 {
+		avgLen = 1
+	}
+
+	scores := map[string]float64{}
+	for i, term := range terms {
+		matched := map[string]float64{}
+		expansions := []string{term}
+		if i == len(terms)-1 {
+			expansions = idx.prefixTerms(term)
+		}
+
+		for _, t := range expansions {
+			m := idx.postings[t]
+			idf := math.Log(1 + (float64(len(idx.docs))-float64(len(m))+0.5)/(float64(len(m))+0.5))
+			for ticket, p := range m {
+				tf := p.weight()
+				norm := tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*idx.docs[ticket].Length/avgLen))
+				matched[ticket] = max(matched[ticket], idf*norm)
+			}
+		}
+
+		if i == 0 {
+			scores = matched
+			continue
+		}
+		for ticket := range scores {
+			if s, ok := matched[ticket]; ok {
+				scores[ticket] += s
+			} else {
+				delete(scores, ticket)
+			}
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for ticket, score := range scores {
+		d := idx.docs[ticket]
+		if !f.match(d) {
+			continue
+		}
+		results = append(results, Result{Doc: d, Score: score, Terms: terms})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Doc.Ticket > results[j].Doc.Ticket
+	})
+	return results
+}
+
+func (idx *Index) prefixTerms(prefix string) []string {
+	var out []string
+	for term := range idx.postings {
+		if strings.HasPrefix(term, prefix) {
+			out = append(out, term)
+		}
+	}
+	return out
+}
+
+func containsFold(s, sub string) bool {
+	return strings.Contains(fold(s), fold(sub))
+}
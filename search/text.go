@@ -0,0 +1,127 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// stopWords are skipped when indexing; tickets mix Portuguese and English.
+var stopWords = map[string]bool{
+	"a": true, "o": true, "as": true, "os": true, "de": true, "da": true, "do": true,
+	"das": true, "dos": true, "e": true, "em": true, "no": true, "na": true,
+	"nos": true, "nas": true, "um": true, "uma": true, "para": true, "por": true,
+	"com": true, "que": true, "se": true, "ao": true, "foi": true,
+	"the": true, "an": true, "and": true, "or": true, "of": true, "to": true,
+	"in": true, "on": true, "is": true, "it": true, "for": true, "at": true,
+}
+
+var accentFold = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ç': 'c', 'ñ': 'n',
+}
+
+// fold lowercases s and strips the accents common in Portuguese, so
+// "Não" and "nao" match.
+func fold(s string) string {
+	return strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if f, ok := accentFold[r]; ok {
+			return f
+		}
+		return r
+	}, s)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Tokenize splits s into folded terms, dropping stop words and single
+// characters.
+func Tokenize(s string) []string {
+	var terms []string
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return !isWordRune(r) }) {
+		w = fold(w)
+		if len([]rune(w)) < 2 || stopWords[w] {
+			continue
+		}
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+// Snippet returns a window of about width runes around the first match of
+// terms in text, passing matched words through highlight. The last term
+// also matches as a prefix, as in Search. It returns "" when nothing
+// matches.
+func Snippet(text string, terms []string, width int, highlight func(string) string) string {
+	type word struct {
+		start, end int
+		match      bool
+	}
+
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	var words []word
+	first := -1
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+		w := word{start: i, end: j, match: matchesTerm(fold(string(runes[i:j])), terms)}
+		if w.match && first < 0 {
+			first = len(words)
+		}
+		words = append(words, w)
+		i = j
+	}
+	if first < 0 {
+		return ""
+	}
+
+	from := max(0, words[first].start-width/3)
+	to := min(len(runes), from+width)
+	// Start and end on word boundaries.
+	for from > 0 && isWordRune(runes[from-1]) {
+		from--
+	}
+	for to < len(runes) && isWordRune(runes[to]) {
+		to++
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, w := range words {
+		if !w.match || w.start < from || w.end > to {
+			continue
+		}
+		b.WriteString(string(runes[pos:w.start]))
+		b.WriteString(highlight(string(runes[w.start:w.end])))
+		pos = w.end
+	}
+	b.WriteString(string(runes[pos:to]))
+	if to < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func matchesTerm(w string, terms []string) bool {
+	if len(terms) == 0 {
+		return false
+	}
+	last := terms[len(terms)-1]
+	return slices.Contains(terms, w) || strings.HasPrefix(w, last)
+}
@@ -0,0 +1,295 @@
+// Package tui is a small full-screen terminal toolkit for the interactive
+// commands: raw mode, key decoding and frame drawing on golang.org/x/term.
+// Each frame is redrawn in full, which is plenty for lists of a few
+// hundred lines.
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ErrNotTerminal is returned by Open when stdin or stdout is not a terminal.
+var ErrNotTerminal = errors.New("interactive mode needs a terminal")
+
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	resetStyle     = "\x1b[0m"
+	clearLine      = "\x1b[K"
+)
+
+// resizePoll is how often ReadKey checks the terminal size.
+const resizePoll = 200 * time.Millisecond
+
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyEnter
+	KeyEsc
+	KeyBackspace
+	KeyDelete
+	KeyTab
+	KeyBacktab
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPgUp
+	KeyPgDn
+	// KeyCtrl is a control character; Rune holds the letter, e.g. 'c'.
+	KeyCtrl
+	// KeyResize reports that the terminal size changed.
+	KeyResize
+	KeyUnknown
+)
+
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// Is reports whether k is the printable rune r.
+func (k Key) Is(r rune) bool {
+	return k.Code == KeyRune && k.Rune == r
+}
+
+// IsCtrl reports whether k is Ctrl plus the letter r.
+func (k Key) IsCtrl(r rune) bool {
+	return k.Code == KeyCtrl && k.Rune == r
+}
+
+// Screen owns the terminal while an interactive command runs.
+type Screen struct {
+	in, out *os.File
+	w       *bufio.Writer
+	state   *term.State
+
+	keys          chan Key
+	errs          chan error
+	width, height int
+}
+
+// Open switches the terminal to raw mode and the alternate screen. Close
+// must be called to restore it.
+func Open() (*Screen, error) {
+	in, out := os.Stdin, os.Stdout
+	if !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return nil, ErrNotTerminal
+	}
+
+	s := &Screen{
+		in:   in,
+		out:  out,
+		w:    bufio.NewWriterSize(out, 64<<10),
+		keys: make(chan Key, 64),
+		errs: make(chan error, 1),
+	}
+	if err := s.makeRaw(); err != nil {
+		return nil, err
+	}
+	if err := s.enterAltScreen(); err != nil {
+		return nil, err
+	}
+	go s.readLoop()
+	return s, nil
+}
+
+func (s *Screen) makeRaw() error {
+	state, err := term.MakeRaw(int(s.in.Fd()))
+	if err != nil {
+		return err
+	}
+	s.state = state
+	return nil
+}
+
+func (s *Screen) enterAltScreen() error {
+	s.width, s.height = s.Size()
+	s.w.WriteString(enterAltScreen)
+	return s.w.Flush()
+}
+
+func (s *Screen) leave() error {
+	s.w.WriteString(resetStyle + leaveAltScreen)
+	s.w.Flush()
+	return term.Restore(int(s.in.Fd()), s.state)
+}
+
+// Close restores the terminal.
+func (s *Screen) Close() error {
+	return s.leave()
+}
+
+// Size returns the terminal width and height, with a fallback of 80x24.
+func (s *Screen) Size() (int, int) {
+	w, h, err := term.GetSize(int(s.out.Fd()))
+	if err != nil || w <= 0 || h <= 0 {
+		return 80, 24
+	}
+	return w, h
+}
+
+// Suspend gives the terminal back to fn, e.g. to print the progress of a
+// download, then waits for a key before returning to the full screen.
+// fn must not read stdin.
+func (s *Screen) Suspend(fn func()) error {
+	if err := s.leave(); err != nil {
+		return err
+	}
+	fn()
+	fmt.Fprint(s.out, "\nPress any key to continue...")
+	if err := s.makeRaw(); err != nil {
+		return err
+	}
+	// Drop what was typed meanwhile, then wait for a fresh key.
+	for len(s.keys) > 0 {
+		<-s.keys
+	}
+	for {
+		k, err := s.ReadKey()
+		if err != nil {
+			return err
+		}
+		if k.Code != KeyResize {
+			break
+		}
+	}
+	return s.enterAltScreen()
+}
+
+func (s *Screen) readLoop() {
+	buf := make([]byte, 256)
+	for {
+		n, err := s.in.Read(buf)
+		if err != nil {
+			s.errs <- err
+			return
+		}
+		for b := buf[:n]; len(b) > 0; {
+			k, size := decodeKey(b)
+			b = b[size:]
+			if k.Code != KeyUnknown {
+				s.keys <- k
+			}
+		}
+	}
+}
+
+// ReadKey blocks until a key is pressed or the terminal is resized.
+func (s *Screen) ReadKey() (Key, error) {
+	tick := time.NewTicker(resizePoll)
+	defer tick.Stop()
+	for {
+		select {
+		case k := <-s.keys:
+			return k, nil
+		case err := <-s.errs:
+			return Key{}, err
+		case <-tick.C:
+			if w, h := s.Size(); w != s.width || h != s.height {
+				s.width, s.height = w, h
+				return Key{Code: KeyResize}, nil
+			}
+		}
+	}
+}
+
+// Draw replaces the screen with lines, cutting each to the terminal width
+// and leaving the rest blank.
+func (s *Screen) Draw(lines []string) error {
+	w, h := s.Size()
+	s.w.WriteString("\x1b[H")
+	for i := 0; i < h; i++ {
+		if i < len(lines) {
+			s.w.WriteString(Cut(lines[i], w))
+		}
+		s.w.WriteString(resetStyle + clearLine)
+		if i < h-1 {
+			s.w.WriteString("\r\n")
+		}
+	}
+	return s.w.Flush()
+}
+
+// decodeKey decodes the key at the start of b and returns its length.
+func decodeKey(b []byte) (Key, int) {
+	switch c := b[0]; {
+	case c == 0x1b:
+		if len(b) == 1 {
+			return Key{Code: KeyEsc}, 1
+		}
+		if b[1] == '[' || b[1] == 'O' {
+			return decodeCSI(b)
+		}
+		return Key{Code: KeyEsc}, 1
+	case c == '\r' || c == '\n':
+		return Key{Code: KeyEnter}, 1
+	case c == 0x7f || c == 0x08:
+		return Key{Code: KeyBackspace}, 1
+	case c == '\t':
+		return Key{Code: KeyTab}, 1
+	case c < 0x20:
+		return Key{Code: KeyCtrl, Rune: rune('a' + c - 1)}, 1
+	}
+
+	r, size := utf8.DecodeRune(b)
+	if r == utf8.RuneError {
+		return Key{Code: KeyUnknown}, size
+	}
+	return Key{Code: KeyRune, Rune: r}, size
+}
+
+// decodeCSI decodes ESC [ params final and ESC O final sequences.
+func decodeCSI(b []byte) (Key, int) {
+	i := 2
+	for i < len(b) && (b[i] >= '0' && b[i] <= '9' || b[i] == ';') {
+		i++
+	}
+	if i >= len(b) {
+		return Key{Code: KeyUnknown}, len(b)
+	}
+	params, final := string(b[2:i]), b[i]
+	n := i + 1
+
+	switch final {
+	case 'A':
+		return Key{Code: KeyUp}, n
+	case 'B':
+		return Key{Code: KeyDown}, n
+	case 'C':
+		return Key{Code: KeyRight}, n
+	case 'D':
+		return Key{Code: KeyLeft}, n
+	case 'H':
+		return Key{Code: KeyHome}, n
+	case 'F':
+		return Key{Code: KeyEnd}, n
+	case 'Z':
+		return Key{Code: KeyBacktab}, n
+	case '~':
+		switch strings.SplitN(params, ";", 2)[0] {
+		case "1", "7":
+			return Key{Code: KeyHome}, n
+		case "4", "8":
+			return Key{Code: KeyEnd}, n
+		case "3":
+			return Key{Code: KeyDelete}, n
+		case "5":
+			return Key{Code: KeyPgUp}, n
+		case "6":
+			return Key{Code: KeyPgDn}, n
+		}
+	}
+	return Key{Code: KeyUnknown}, n
+}
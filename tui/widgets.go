@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/muesli/reflow/ansi"
+	"github.com/muesli/reflow/truncate"
+)
+
+const (
+	reverse = "\x1b[7m"
+	bold    = "\x1b[1m"
+)
+
+// Width is the printable width of s, ignoring color codes.
+func Width(s string) int {
+	return ansi.PrintableRuneWidth(s)
+}
+
+// Cut truncates s to width printable columns, keeping color codes intact.
+func Cut(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if Width(s) <= width {
+		return s
+	}
+	return truncate.StringWithTail(s, uint(width), "…") + resetStyle
+}
+
+// Pad cuts or pads s with spaces to exactly width columns.
+func Pad(s string, width int) string {
+	s = Cut(s, width)
+	if n := width - Width(s); n > 0 {
+		s += strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// Reverse renders s in reverse video across width columns, e.g. for the
+// selected row or a title bar. Color resets inside s keep the reverse on.
+func Reverse(s string, width int) string {
+	s = strings.ReplaceAll(Pad(s, width), resetStyle, resetStyle+reverse)
+	return reverse + s + resetStyle
+}
+
+// Bold renders s in bold.
+func Bold(s string) string {
+	return bold + s + resetStyle
+}
+
+// Scroll returns the first visible row so that cursor stays within a window
+// of height rows starting at offset.
+func Scroll(offset, cursor, height, total int) int {
+	if height <= 0 {
+		return 0
+	}
+	if cursor < offset {
+		offset = cursor
+	}
+	if cursor >= offset+height {
+		offset = cursor - height + 1
+	}
+	return max(0, min(offset, total-height))
+}
+
+// Input is a single-line text field.
+type Input struct {
+	Value string
+}
+
+// Handle applies an editing key and reports whether it was consumed.
+func (in *Input) Handle(k Key) bool {
+	switch {
+	case k.Code == KeyRune:
+		in.Value += string(k.Rune)
+	case k.Code == KeyBackspace:
+		if in.Value != "" {
+			_, size := utf8.DecodeLastRuneInString(in.Value)
+			in.Value = in.Value[:len(in.Value)-size]
+		}
+	case k.IsCtrl('u'):
+		in.Value = ""
+	case k.IsCtrl('w'):
+		v := strings.TrimRight(in.Value, " ")
+		in.Value = v[:strings.LastIndex(v, " ")+1]
+	default:
+		return false
+	}
+	return true
+}
+
+// Field is one line of a Form. Fields with Options are cycled with the
+// left and right arrows instead of typed into. Key names the field for
+// Value and Set, so the Label can be translated; it defaults to the Label.
+type Field struct {
+	Key     string
+	Label   string
+	Options []string
+	Input
+}
+
+func (fl Field) key() string {
+	if fl.Key != "" {
+		return fl.Key
+	}
+	return fl.Label
+}
+
+// Form is a small dialog of fields. Tab and the arrows move between
+// fields, Enter on the last field submits and Esc cancels.
+type Form struct {
+	Title  string
+	Fields []Field
+	Focus  int
+	// Message is shown under the fields, e.g. a validation error.
+	Message string
+}
+
+// FormResult is what a key did to a form.
+type FormResult int
+
+const (
+	FormEditing FormResult = iota
+	FormSubmitted
+	FormCancelled
+)
+
+// Value returns the value of the field with the given key.
+func (f *Form) Value(key string) string {
+	for _, fl := range f.Fields {
+		if fl.key() == key {
+			return strings.TrimSpace(fl.Value)
+		}
+	}
+	return ""
+}
+
+// Set sets the value of the field with the given key.
+func (f *Form) Set(key, value string) {
+	for i := range f.Fields {
+		if f.Fields[i].key() == key {
+			f.Fields[i].Value = value
+		}
+	}
+}
+
+func (f *Form) Handle(k Key) FormResult {
+	field := &f.Fields[f.Focus]
+	switch {
+	case k.Code == KeyEsc:
+		return FormCancelled
+	case k.Code == KeyEnter:
+		if f.Focus == len(f.Fields)-1 {
+			return FormSubmitted
+		}
+		f.Focus++
+	case k.Code == KeyTab || k.Code == KeyDown:
+		f.Focus = (f.Focus + 1) % len(f.Fields)
+	case k.Code == KeyBacktab || k.Code == KeyUp:
+		f.Focus = (f.Focus + len(f.Fields) - 1) % len(f.Fields)
+	case len(field.Options) > 0 && (k.Code == KeyLeft || k.Code == KeyRight):
+		i := 0
+		for j, o := range field.Options {
+			if o == field.Value {
+				i = j
+			}
+		}
+		if k.Code == KeyRight {
+			i = (i + 1) % len(field.Options)
+		} else {
+			i = (i + len(field.Options) - 1) % len(field.Options)
+		}
+		field.Value = field.Options[i]
+	case len(field.Options) == 0:
+		field.Handle(k)
+	}
+	return FormEditing
+}
+
+// Lines renders the form for a box of the given width.
+func (f *Form) Lines(width int) []string {
+	labelW := 0
+	for _, fl := range f.Fields {
+		labelW = max(labelW, Width(fl.Label))
+	}
+
+	lines := []string{Bold(f.Title), ""}
+	for i, fl := range f.Fields {
+		value := fl.Value
+		if len(fl.Options) > 0 {
+			value = "‹ " + value + " ›"
+		}
+		line := "  " + Pad(fl.Label, labelW) + "  "
+		if i == f.Focus {
+			cursor := "_"
+			if len(fl.Options) > 0 {
+				cursor = ""
+			}
+			line += Reverse(value+cursor, max(20, width-Width(line)-2))
+		} else {
+			line += value
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "")
+	if f.Message != "" {
+		lines = append(lines, "  "+f.Message, "")
+	}
+	lines = append(lines, "  Tab/↑↓ move  ←→ choose  Enter next/submit  Esc cancel")
+	return lines
+}
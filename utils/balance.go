@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/mantis/mantis"
+)
+
+// BalanceWeek is one row of the hour-bank ledger.
+type BalanceWeek struct {
+	Year     int
+	Week     int
+	Start    string // first day of the week inside the range (YYYY-MM-DD)
+	End      string // last day of the week inside the range (YYYY-MM-DD)
+	Expected float64
+	Worked   float64
+	Delta    float64
+	Balance  float64 // running balance at the end of the week
+}
+
+// JourneyFor returns the expected hours for a business day, honoring the
+// profile's journey overrides before falling back to DailyJourney (or 8h).
+func JourneyFor(prof config.Profile, day time.Time) float64 {
+	key := day.Format("2006-01-02")
+	for _, o := range prof.JourneyOverrides {
+		if key >= o.From && (o.To == "" || key <= o.To) {
+			return o.Hours
+		}
+	}
+	if prof.DailyJourney > 0 {
+		return prof.DailyJourney
+	}
+	return 8.0
+}
+
+// OpeningBalanceFor returns the latest opening balance dated on or before
+// day. ok is false when the profile has none.
+func OpeningBalanceFor(prof config.Profile, day time.Time) (config.OpeningBalance, bool) {
+	key := day.Format("2006-01-02")
+
+	var best config.OpeningBalance
+	found := false
+	for _, ob := range prof.OpeningBalances {
+		if ob.Date <= key && (!found || ob.Date > best.Date) {
+			best, found = ob, true
+		}
+	}
+	return best, found
+}
+
+// ComputeBalance walks every day in [from, to] and accumulates the hour
+// bank. Only business days (weekdays not listed in nonBusiness, keyed by
+// YYYY-MM-DD) are expected to hold a journey; hours logged on any day count
+// as worked. Returns the weekly ledger and the final balance.
+func ComputeBalance(
+	timesheets []mantis.TimesheetsResponse,
+	nonBusiness map[string]bool,
+	prof config.Profile,
+	from, to time.Time,
+	opening float64,
+) ([]BalanceWeek, float64) {
+	worked := map[string]float64{}
+	for date, entries := range groupByDate(timesheets) {
+		for _, ts := range entries {
+			worked[date] += ts.Quantity
+		}
+	}
+
+	balance := opening
+	var weeks []BalanceWeek
+	var current *BalanceWeek
+
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		year, week := d.ISOWeek()
+
+		if current == nil || current.Year != year || current.Week != week {
+			weeks = append(weeks, BalanceWeek{Year: year, Week: week, Start: key})
+			current = &weeks[len(weeks)-1]
+		}
+
+		expected := 0.0
+		weekday := d.Weekday()
+		if weekday != time.Saturday && weekday != time.Sunday && !nonBusiness[key] {
+			expected = JourneyFor(prof, d)
+		}
+
+		current.End = key
+		current.Expected += expected
+		current.Worked += worked[key]
+		current.Delta = current.Worked - current.Expected
+		balance += worked[key] - expected
+		current.Balance = balance
+	}
+
+	return weeks, balance
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/mantis/mantis"
+)
+
+func testDay(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestJourneyFor(t *testing.T) {
+	prof := config.Profile{
+		DailyJourney: 8,
+		JourneyOverrides: []config.JourneyOverride{
+			{From: "2026-03-04", To: "2026-03-10", Hours: 6},
+			{From: "2026-06-01", Hours: 4},
+		},
+	}
+	tests := []struct {
+		name string
+		prof config.Profile
+		day  string
+		want float64
+	}{
+		{"before the override", prof, "2026-03-03", 8},
+		{"first day of the override", prof, "2026-03-04", 6},
+		{"last day of the override", prof, "2026-03-10", 6},
+		{"after the override", prof, "2026-03-11", 8},
+		{"open-ended override", prof, "2027-01-15", 4},
+		{"no journey set", config.Profile{}, "2026-03-03", 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JourneyFor(tt.prof, testDay(t, tt.day)); got != tt.want {
+				t.Errorf("JourneyFor(%s) = %v, want %v", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeBalance(t *testing.T) {
+	prof := config.Profile{
+		DailyJourney: 8,
+		JourneyOverrides: []config.JourneyOverride{
+			{From: "2026-03-04", To: "2026-03-10", Hours: 6},
+		},
+	}
+	var timesheets []mantis.TimesheetsResponse
+	for _, day := range []string{
+		"2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05", "2026-03-06",
+		"2026-03-09", "2026-03-10", "2026-03-11", "2026-03-13",
+	} {
+		timesheets = append(timesheets, mantis.TimesheetsResponse{DateDoc: day + "T00:00:00Z", Quantity: 8})
+	}
+	// Weekend work counts as worked without being expected.
+	timesheets = append(timesheets, mantis.TimesheetsResponse{DateDoc: "2026-03-07T00:00:00Z", Quantity: 4})
+	nonBusiness := map[string]bool{"2026-03-12": true}
+
+	weeks, balance := ComputeBalance(timesheets, nonBusiness, prof,
+		testDay(t, "2026-03-02"), testDay(t, "2026-03-13"), 1.5)
+
+	want := []BalanceWeek{
+		// 8 + 8 + 6 + 6 + 6 expected, the override starting mid-week.
+		{Year: 2026, Week: 10, Start: "2026-03-02", End: "2026-03-08", Expected: 34, Worked: 44, Delta: 10, Balance: 11.5},
+		// 6 + 6 + 8 + 8 expected, the override ending mid-week and the
+		// holiday expecting nothing.
+		{Year: 2026, Week: 11, Start: "2026-03-09", End: "2026-03-13", Expected: 28, Worked: 32, Delta: 4, Balance: 15.5},
+	}
+	if len(weeks) != len(want) {
+		t.Fatalf("ComputeBalance returned %d week(s), want %d: %+v", len(weeks), len(want), weeks)
+	}
+	for i := range want {
+		if weeks[i] != want[i] {
+			t.Errorf("week %d = %+v, want %+v", i, weeks[i], want[i])
+		}
+	}
+	if balance != 15.5 {
+		t.Errorf("balance = %v, want 15.5", balance)
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"regexp"
+	"time"
+)
+
+// reDateBound matches date comparisons in an already-expanded qlvm query,
+// e.g. `date >= 2026-03-01` or `date < '2026-04-01'`.
+var reDateBound = regexp.MustCompile(
+	`(?i)\bdate\s*(>=|<=|>|<|=)\s*['"]?(\d{4}-\d{2}-\d{2})['"]?`,
+)
+
+// QueryDateBounds inspects the date comparisons of a qlvm query and returns
+// the widest [from, to] window they describe. A zero time means the query
+// does not bound that side.
+//
+// Tokens must already be expanded (see ExpandTokens / ResolveFilter).
+// Bounds are merged loosely: the earliest lower bound and the latest upper
+// bound win, so the window is always a superset of what the query can match
+// and the query itself still does the exact filtering afterwards.
+//
+// Examples:
+//
+//	"date >= 2026-03-01"                     → 2026-03-01, zero
+//	"date >= 2026-03-01 AND date < 2026-04-01" → 2026-03-01, 2026-04-01
+//	"date = 2026-03-10"                      → 2026-03-10, 2026-03-10
+//	"project = toyo"                         → zero, zero
+func QueryDateBounds(query string) (from, to time.Time) {
+	for _, m := range reDateBound.FindAllStringSubmatch(query, -1) {
+		t, err := time.ParseInLocation("2006-01-02", m[2], time.Local)
+		if err != nil {
+			continue
+		}
+
+		switch m[1] {
+		case ">", ">=":
+			from = earliest(from, t)
+		case "<", "<=":
+			to = latest(to, t)
+		case "=":
+			from = earliest(from, t)
+			to = latest(to, t)
+		}
+	}
+	return from, to
+}
+
+func earliest(current, t time.Time) time.Time {
+	if current.IsZero() || t.Before(current) {
+		return t
+	}
+	return current
+}
+
+func latest(current, t time.Time) time.Time {
+	if current.IsZero() || t.After(current) {
+		return t
+	}
+	return current
+}
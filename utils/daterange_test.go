@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryDateBounds(t *testing.T) {
+	day := func(s string) time.Time {
+		d, err := time.ParseInLocation("2006-01-02", s, time.Local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	tests := []struct {
+		name     string
+		query    string
+		from, to string
+	}{
+		{"unbounded", "project = toyo", "", ""},
+		{"lower bound", "date >= 2026-03-01", "2026-03-01", ""},
+		{"upper bound", "date < '2026-04-01'", "", "2026-04-01"},
+		{"equality", `date = "2026-03-10"`, "2026-03-10", "2026-03-10"},
+		{"close day", "date >= 2026-02-25 AND date <= 2026-02-25", "2026-02-25", "2026-02-25"},
+		{"open day", "date >= 2026-02-26 AND date <= 2026-03-25", "2026-02-26", "2026-03-25"},
+		{"december to january", "date >= 2025-12-26 AND date <= 2026-01-25", "2025-12-26", "2026-01-25"},
+		{"widest window wins", "(date >= 2026-03-05 AND date <= 2026-03-10) OR (date >= 2026-02-20 AND date < 2026-03-31)", "2026-02-20", "2026-03-31"},
+		{"case and spacing", "DATE>=2026-01-01 and Date<=2026-01-31", "2026-01-01", "2026-01-31"},
+		{"invalid date ignored", "date >= 2026-13-40 AND date <= 2026-03-01", "", "2026-03-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := QueryDateBounds(tt.query)
+			var wantFrom, wantTo time.Time
+			if tt.from != "" {
+				wantFrom = day(tt.from)
+			}
+			if tt.to != "" {
+				wantTo = day(tt.to)
+			}
+			if !from.Equal(wantFrom) || !to.Equal(wantTo) {
+				t.Errorf("QueryDateBounds(%q) = %s, %s, want %s, %s", tt.query,
+					from.Format("2006-01-02"), to.Format("2006-01-02"),
+					wantFrom.Format("2006-01-02"), wantTo.Format("2006-01-02"))
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Salvadego/mantis/mantis"
+)
+
+// closedStatusPrefixes are the report statuses of tickets nobody is
+// expected to work on any more, lowercased.
+var closedStatusPrefixes = []string{
+	"encerrad", "fechad", "conclu", "resolvid",
+	"closed", "resolved", "cancel",
+}
+
+// IsClosedStatus reports whether a ticket status means the ticket is done.
+func IsClosedStatus(status string) bool {
+	s := strings.ToLower(strings.TrimSpace(status))
+	for _, p := range closedStatusPrefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// TicketEffort is the time logged against one ticket.
+type TicketEffort struct {
+	Ticket   string
+	Hours    float64
+	Entries  int
+	LastDate time.Time
+}
+
+// TicketEfforts sums timesheets by ticket number. Timesheets without a
+// ticket are ignored, and entries seen twice (same TimesheetID) count once.
+func TicketEfforts(timesheets []mantis.TimesheetsResponse) map[string]TicketEffort {
+	seen := make(map[int]bool, len(timesheets))
+	out := make(map[string]TicketEffort)
+	for _, ts := range timesheets {
+		ticket := strings.TrimSpace(ts.TicketNo)
+		if ticket == "" {
+			continue
+		}
+		if ts.TimesheetID != 0 {
+			if seen[ts.TimesheetID] {
+				continue
+			}
+			seen[ts.TimesheetID] = true
+		}
+
+		e := out[ticket]
+		e.Ticket = ticket
+		e.Hours += ts.Quantity
+		e.Entries++
+		if d, err := time.Parse(time.RFC3339, ts.DateDoc); err == nil && d.After(e.LastDate) {
+			e.LastDate = d
+		}
+		out[ticket] = e
+	}
+	return out
+}
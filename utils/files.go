@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const maxFileNameBytes = 200
+
+// windowsReserved are device names that cannot be used as file names on
+// Windows, with or without an extension.
+var windowsReserved = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// SanitizeFileName turns a server-provided name into a safe base name: any
+// directory part is dropped, control and reserved characters are replaced,
+// leading dots are removed so nothing ends up hidden or relative, and the
+// result is capped in length keeping the extension.
+func SanitizeFileName(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsControl(r):
+			return -1
+		case strings.ContainsRune(`<>:"|?*`, r):
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.TrimLeft(strings.TrimSpace(name), ".")
+	name = strings.TrimRight(name, ". ")
+
+	if name == "" {
+		return "attachment"
+	}
+
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+	if windowsReserved[strings.ToUpper(stem)] {
+		name = "_" + name
+	}
+
+	if len(name) > maxFileNameBytes {
+		ext := filepath.Ext(name)
+		if len(ext) > 20 {
+			ext = ""
+		}
+		stem := name[:maxFileNameBytes-len(ext)]
+		// Do not cut a multi-byte rune in half.
+		for !utf8.ValidString(stem) {
+			stem = stem[:len(stem)-1]
+		}
+		name = stem + ext
+	}
+	return name
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "report.pdf", "report.pdf"},
+		{"unix traversal", "../../etc/passwd", "passwd"},
+		{"windows traversal", `..\..\Windows\win.ini`, "win.ini"},
+		{"absolute", "/etc/shadow", "shadow"},
+		{"only dots", "..", "attachment"},
+		{"hidden", ".bashrc", "bashrc"},
+		{"trailing dots and spaces", "notes. . ", "notes"},
+		{"reserved characters", `a<b>c:d"e|f?g*h.txt`, "a_b_c_d_e_f_g_h.txt"},
+		{"control characters", "bad\x00\nname.txt", "badname.txt"},
+		{"reserved device", "CON", "_CON"},
+		{"reserved device with extension", "nul.txt", "_nul.txt"},
+		{"reserved device lower case", "com1.log", "_com1.log"},
+		{"not reserved", "CONSOLE.txt", "CONSOLE.txt"},
+		{"empty", "", "attachment"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeFileName(tt.in); got != tt.want {
+				t.Errorf("SanitizeFileName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeFileNameLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantExt string
+	}{
+		{"ascii", strings.Repeat("a", 300) + ".pdf", ".pdf"},
+		{"multi-byte", "a" + strings.Repeat("é", 150) + ".txt", ".txt"},
+		{"long extension", "a." + strings.Repeat("x", 300), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SanitizeFileName(tt.in)
+			if len(got) > maxFileNameBytes {
+				t.Errorf("len(SanitizeFileName) = %d, want at most %d", len(got), maxFileNameBytes)
+			}
+			if !strings.HasSuffix(got, tt.wantExt) {
+				t.Errorf("SanitizeFileName kept %q, want the %q extension", got, tt.wantExt)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("SanitizeFileName cut a rune in half: %q", got)
+			}
+		})
+	}
+}
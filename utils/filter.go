@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/i18n"
 	"github.com/Salvadego/IntraCLI/types"
 	"github.com/Salvadego/mantis/mantis"
 	"github.com/Salvadego/qlvm"
@@ -61,6 +62,29 @@ type DailySummaryRecord struct {
 	idx int `qlvm:"-"`
 }
 
+// TicketRecord is the qlvm-queryable projection of a dashboard ticket.
+//
+// Example queries:
+//
+//	sla < 60 AND priority <= 2
+//	status = 'Em Atendimento'
+//	.sefaz                 (description contains shorthand)
+//	#acme                  (contract title contains shorthand)
+//	created >= '30d-ago'
+//	contract = 4100123
+type TicketRecord struct {
+	Number        string `qlvm:"number"`
+	Status        string `qlvm:"status"`
+	Priority      int    `qlvm:"priority"`
+	SLA           int    `qlvm:"sla"`
+	Created       string `qlvm:"created,date"`
+	Contract      string `qlvm:"contract"`
+	ContractTitle string `qlvm:"contract_title"`
+	Description   string `qlvm:"description"`
+
+	idx int `qlvm:"-"`
+}
+
 // Engine is the shared qlvm engine for timesheet queries.
 // Initialized at package load — never nil.
 var Engine = qlvm.New(
@@ -77,6 +101,15 @@ var DayEngine = qlvm.New(
 		Suffix('?', qlvm.Exists()),
 )
 
+// TicketEngine is the shared qlvm engine for ticket queries.
+// Initialized at package load — never nil.
+var TicketEngine = qlvm.New(
+	qlvm.SchemaFromStruct[TicketRecord]().
+		Prefix('.', "description", qlvm.Contains).
+		Prefix('#', "contract_title", qlvm.Contains).
+		Suffix('?', qlvm.Exists()),
+)
+
 // newTimesheetRecord converts a mantis response into a queryable record,
 // resolving the project alias from the profile when possible.
 func newTimesheetRecord(ts mantis.TimesheetsResponse, profile config.Profile, idx int) TimesheetRecord {
@@ -144,6 +177,58 @@ func Apply(
 	return result, nil
 }
 
+// PriorityRank parses the rank of Mantis priorities such as "1: Muito alta".
+func PriorityRank(p string) int {
+	v, _ := strconv.Atoi(strings.TrimSpace(strings.Split(p, ":")[0]))
+	return v
+}
+
+func newTicketRecord(t mantis.TicketResponse, idx int) TicketRecord {
+	created := ""
+	if c, err := time.Parse("20060102150405", t.TicketCreated); err == nil {
+		created = c.Format("2006-01-02")
+	}
+	return TicketRecord{
+		Number:        t.TicketNumber,
+		Status:        t.Status,
+		Priority:      PriorityRank(t.Priority),
+		SLA:           ParsePercent(t.PercSLA),
+		Created:       created,
+		Contract:      t.IDContrato,
+		ContractTitle: t.TituloContrato,
+		Description:   t.Description,
+		idx:           idx,
+	}
+}
+
+// ApplyTickets filters tickets using a qlvm query string and returns the
+// matching subset. An empty query string returns all tickets unchanged.
+func ApplyTickets(query string, tickets []mantis.TicketResponse) ([]mantis.TicketResponse, error) {
+	if query == "" {
+		return tickets, nil
+	}
+
+	records := make([]TicketRecord, len(tickets))
+	for i, t := range tickets {
+		records[i] = newTicketRecord(t, i)
+	}
+
+	matched, err := qlvm.Filter(TicketEngine, query, records,
+		func(r TicketRecord) qlvm.Resolver {
+			return qlvm.ResolverOf(r)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]mantis.TicketResponse, len(matched))
+	for i, r := range matched {
+		result[i] = tickets[r.idx]
+	}
+	return result, nil
+}
+
 // ApplyFilter is a convenience wrapper around Apply that swallows the error and
 // logs a warning, returning the original slice on failure.  Use Apply when you
 // need proper error handling.
@@ -325,7 +410,7 @@ func ResolveFilter(value string, saved map[string]string) (string, error) {
 		if q, ok := saved[name]; ok {
 			return q
 		}
-		lastErr = fmt.Errorf("saved filter %q not found", name)
+		lastErr = i18n.Errorf("saved filter %q not found", name)
 		return match
 	})
 
@@ -0,0 +1,307 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// safeTags are kept by SanitizeHTML, with the attributes allowed on each.
+var safeTags = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.Div: nil, atom.Span: nil, atom.Hr: nil,
+	atom.B: nil, atom.Strong: nil, atom.I: nil, atom.Em: nil, atom.U: nil, atom.S: nil,
+	atom.H1: nil, atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.Ul: nil, atom.Ol: nil, atom.Li: nil,
+	atom.Pre: nil, atom.Code: nil, atom.Blockquote: nil,
+	atom.Table: nil, atom.Thead: nil, atom.Tbody: nil, atom.Tr: nil,
+	atom.Td: {"colspan", "rowspan"}, atom.Th: {"colspan", "rowspan"},
+	atom.A: {"href", "title"},
+}
+
+// droppedTags are removed together with their content.
+var droppedTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true,
+	atom.Embed: true, atom.Noscript: true, atom.Template: true, atom.Head: true,
+}
+
+var htmlTagRe = regexp.MustCompile(`(?i)</?[a-z][^>]*>`)
+
+// IsHTML reports whether s contains markup. Mantis texts are either HTML or
+// plain text with meaningful newlines.
+func IsHTML(s string) bool {
+	return htmlTagRe.MatchString(s)
+}
+
+// SanitizeHTML keeps the markup of s that is safe to embed in another
+// document: formatting, lists, tables and http(s)/mailto links. Everything
+// else is dropped, keeping its text, except scripts, styles and embeds,
+// which are dropped entirely. Plain text is escaped with newlines turned
+// into <br>.
+func SanitizeHTML(s string) string {
+	if !IsHTML(s) {
+		return strings.ReplaceAll(html.EscapeString(s), "\n", "<br>\n")
+	}
+
+	var b strings.Builder
+	z := nethtml.NewTokenizer(strings.NewReader(s))
+	skip := 0
+	for {
+		tt := z.Next()
+		if tt == nethtml.ErrorToken {
+			if z.Err() == io.EOF {
+				break
+			}
+			return b.String()
+		}
+
+		tok := z.Token()
+		switch tt {
+		case nethtml.TextToken:
+			if skip == 0 {
+				b.WriteString(html.EscapeString(tok.Data))
+			}
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			if droppedTags[tok.DataAtom] {
+				if tt == nethtml.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			allowed, ok := safeTags[tok.DataAtom]
+			if !ok || skip > 0 {
+				continue
+			}
+			b.WriteString("<" + tok.DataAtom.String())
+			for _, attr := range tok.Attr {
+				if !slices.Contains(allowed, attr.Key) {
+					continue
+				}
+				if attr.Key == "href" && !SafeURL(attr.Val) {
+					continue
+				}
+				fmt.Fprintf(&b, ` %s="%s"`, attr.Key, html.EscapeString(attr.Val))
+			}
+			if tok.DataAtom == atom.A {
+				b.WriteString(` rel="noopener noreferrer"`)
+			}
+			b.WriteString(">")
+		case nethtml.EndTagToken:
+			if droppedTags[tok.DataAtom] {
+				skip = max(0, skip-1)
+				continue
+			}
+			if _, ok := safeTags[tok.DataAtom]; ok && skip == 0 && tok.DataAtom != atom.Br && tok.DataAtom != atom.Hr {
+				b.WriteString("</" + tok.DataAtom.String() + ">")
+			}
+		}
+	}
+	return b.String()
+}
+
+// SafeURL accepts http, https and mailto links.
+func SafeURL(u string) bool {
+	u = strings.ToLower(strings.TrimSpace(u))
+	return strings.HasPrefix(u, "http://") ||
+		strings.HasPrefix(u, "https://") ||
+		strings.HasPrefix(u, "mailto:")
+}
+
+var (
+	mdSpaceRe     = regexp.MustCompile(`[ \t\r\n]+`)
+	mdBlankLineRe = regexp.MustCompile(`\n[ \t]*\n(?:[ \t]*\n)+`)
+)
+
+// HTMLToMarkdown converts the markup of a Mantis text to Markdown. Plain
+// text is returned unchanged.
+func HTMLToMarkdown(s string) string {
+	if !IsHTML(s) {
+		return strings.TrimSpace(s)
+	}
+
+	nodes, err := nethtml.ParseFragment(strings.NewReader(s), &nethtml.Node{
+		Type:     nethtml.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return strings.TrimSpace(s)
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		b.WriteString(markdownNode(n, false))
+	}
+
+	out := mdBlankLineRe.ReplaceAllString(b.String(), "\n\n")
+	lines := strings.Split(out, "\n")
+	for i, line := range lines {
+		if !strings.HasSuffix(line, "  ") {
+			lines[i] = strings.TrimRight(line, " \t")
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func markdownChildren(n *nethtml.Node, pre bool) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(markdownNode(c, pre))
+	}
+	return b.String()
+}
+
+func markdownNode(n *nethtml.Node, pre bool) string {
+	switch n.Type {
+	case nethtml.TextNode:
+		if pre {
+			return n.Data
+		}
+		return mdSpaceRe.ReplaceAllString(n.Data, " ")
+	case nethtml.ElementNode:
+	default:
+		return markdownChildren(n, pre)
+	}
+
+	if droppedTags[n.DataAtom] {
+		return ""
+	}
+
+	inner := func() string { return markdownChildren(n, pre) }
+	block := func(s string) string { return "\n\n" + strings.TrimSpace(s) + "\n\n" }
+	inline := func(mark string) string {
+		s := strings.TrimSpace(inner())
+		if s == "" {
+			return ""
+		}
+		return mark + s + mark
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "  \n"
+	case atom.Hr:
+		return "\n\n---\n\n"
+	case atom.P, atom.Div:
+		return block(inner())
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		return block(strings.Repeat("#", level) + " " + strings.TrimSpace(inner()))
+	case atom.B, atom.Strong:
+		return inline("**")
+	case atom.I, atom.Em:
+		return inline("*")
+	case atom.S, atom.Del:
+		return inline("~~")
+	case atom.Code:
+		if pre {
+			return inner()
+		}
+		return inline("`")
+	case atom.Pre:
+		return "\n\n```\n" + strings.Trim(markdownChildren(n, true), "\n") + "\n```\n\n"
+	case atom.A:
+		text := strings.TrimSpace(inner())
+		href := attrValue(n, "href")
+		if href == "" || !SafeURL(href) {
+			return text
+		}
+		if text == "" || text == href {
+			return "<" + href + ">"
+		}
+		return "[" + text + "](" + href + ")"
+	case atom.Img:
+		src := attrValue(n, "src")
+		if !SafeURL(src) {
+			return attrValue(n, "alt")
+		}
+		return "![" + attrValue(n, "alt") + "](" + src + ")"
+	case atom.Blockquote:
+		body := strings.TrimSpace(inner())
+		return block("> " + strings.ReplaceAll(body, "\n", "\n> "))
+	case atom.Ul, atom.Ol:
+		return "\n\n" + markdownList(n, pre) + "\n\n"
+	case atom.Table:
+		return "\n\n" + markdownTable(n) + "\n\n"
+	default:
+		return inner()
+	}
+}
+
+func markdownList(list *nethtml.Node, pre bool) string {
+	var items []string
+	i := 0
+	for c := list.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != nethtml.ElementNode || c.DataAtom != atom.Li {
+			continue
+		}
+		i++
+		marker := "- "
+		if list.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", i)
+		}
+
+		body := strings.TrimSpace(mdBlankLineRe.ReplaceAllString(markdownChildren(c, pre), "\n"))
+		indent := strings.Repeat(" ", len(marker))
+		items = append(items, marker+strings.ReplaceAll(body, "\n", "\n"+indent))
+	}
+	return strings.Join(items, "\n")
+}
+
+func markdownTable(table *nethtml.Node) string {
+	var rows [][]string
+	var walk func(n *nethtml.Node)
+	walk = func(n *nethtml.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != nethtml.ElementNode {
+				continue
+			}
+			if c.DataAtom != atom.Tr {
+				walk(c)
+				continue
+			}
+			var row []string
+			for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == nethtml.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+					text := strings.TrimSpace(mdSpaceRe.ReplaceAllString(markdownChildren(cell, false), " "))
+					row = append(row, strings.ReplaceAll(text, "|", `\|`))
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	walk(table)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	line := func(row []string) string {
+		cells := make([]string, cols)
+		copy(cells, row)
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	out := []string{line(rows[0]), "|" + strings.Repeat(" --- |", cols)}
+	for _, row := range rows[1:] {
+		out = append(out, line(row))
+	}
+	return strings.Join(out, "\n")
+}
+
+func attrValue(n *nethtml.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		want     []string
+		dontWant []string
+	}{
+		{
+			name:     "script dropped with its content",
+			in:       `<p>before</p><script>alert(1)</script><p>after</p>`,
+			want:     []string{"<p>before</p>", "<p>after</p>"},
+			dontWant: []string{"script", "alert"},
+		},
+		{
+			name:     "event handler attribute dropped",
+			in:       `<p>x</p><img src="x" onerror="alert(1)"><span onmouseover="alert(2)">y</span>`,
+			want:     []string{"<span>y</span>"},
+			dontWant: []string{"onerror", "onmouseover", "<img", "alert"},
+		},
+		{
+			name:     "javascript href dropped",
+			in:       `<a href="javascript:alert(1)">click</a>`,
+			want:     []string{`<a rel="noopener noreferrer">click</a>`},
+			dontWant: []string{"javascript"},
+		},
+		{
+			name:     "javascript href with spaces and case",
+			in:       `<a href="  JavaScript:alert(1)">click</a>`,
+			dontWant: []string{"avaScript", "alert"},
+		},
+		{
+			name: "https link kept",
+			in:   `<a href="https://example.com/?a=1&b=2" title="t">ok</a>`,
+			want: []string{`<a href="https://example.com/?a=1&amp;b=2" title="t" rel="noopener noreferrer">ok</a>`},
+		},
+		{
+			name:     "style and iframe dropped",
+			in:       `<div><style>p{}</style><iframe src="https://x"></iframe>text</div>`,
+			want:     []string{"<div>text</div>"},
+			dontWant: []string{"style", "iframe"},
+		},
+		{
+			name: "plain text escaped",
+			in:   "a < b\nc",
+			want: []string{"a &lt; b<br>\nc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SanitizeHTML(tt.in)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("SanitizeHTML(%q) = %q, want it to contain %q", tt.in, got, w)
+				}
+			}
+			for _, w := range tt.dontWant {
+				if strings.Contains(got, w) {
+					t.Errorf("SanitizeHTML(%q) = %q, want no %q", tt.in, got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestSafeURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://example.com", true},
+		{"http://example.com", true},
+		{"mailto:someone@example.com", true},
+		{" HTTPS://example.com", true},
+		{"javascript:alert(1)", false},
+		{" javascript:alert(1)", false},
+		{"data:text/html,<script>alert(1)</script>", false},
+		{"vbscript:msgbox", false},
+		{"//example.com", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := SafeURL(tt.url); got != tt.want {
+			t.Errorf("SafeURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
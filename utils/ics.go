@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ICSEvent is a VEVENT of an iCalendar feed. All-day events use the dates
+// of Start and End (exclusive); other events are written in UTC.
+type ICSEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Categories  []string
+	Start, End  time.Time
+	AllDay      bool
+}
+
+// icsLineLimit is the maximum length of a content line in octets.
+const icsLineLimit = 75
+
+// WriteICS writes events as an iCalendar (RFC 5545) document named name.
+// stamp is the DTSTAMP of every event.
+func WriteICS(w io.Writer, name string, events []ICSEvent, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(prop, value string) {
+		writeICSLine(bw, prop+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//IntraCLI//Timesheets//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("X-WR-CALNAME", escapeICS(name))
+	for _, e := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", escapeICS(e.UID))
+		line("DTSTAMP", stamp.UTC().Format("20060102T150405Z"))
+		if e.AllDay {
+			line("DTSTART;VALUE=DATE", e.Start.Format("20060102"))
+			line("DTEND;VALUE=DATE", e.End.Format("20060102"))
+			line("TRANSP", "TRANSPARENT")
+		} else {
+			line("DTSTART", e.Start.UTC().Format("20060102T150405Z"))
+			line("DTEND", e.End.UTC().Format("20060102T150405Z"))
+		}
+		line("SUMMARY", escapeICS(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escapeICS(e.Description))
+		}
+		if len(e.Categories) > 0 {
+			cats := make([]string, len(e.Categories))
+			for i, c := range e.Categories {
+				cats[i] = escapeICS(c)
+			}
+			line("CATEGORIES", strings.Join(cats, ","))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeICS(s string) string {
+	return icsEscaper.Replace(s)
+}
+
+// writeICSLine writes a content line folded at icsLineLimit octets,
+// without splitting UTF-8 sequences.
+func writeICSLine(w *bufio.Writer, s string) {
+	limit := icsLineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts.
+		limit = icsLineLimit - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
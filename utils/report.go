@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"sort"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/Salvadego/IntraCLI/types"
+	"github.com/Salvadego/mantis/mantis"
+)
+
+// NoTicketKey labels entries logged without a ticket in reports.
+const NoTicketKey = "(no ticket)"
+
+// ReportRow is one aggregated line of a distribution report.
+type ReportRow struct {
+	Key     string  `json:"key"`
+	Hours   float64 `json:"hours"`
+	Percent float64 `json:"percent"`
+	Entries int     `json:"entries"`
+	// Target is the intended share in percent; 0 when none is declared.
+	Target float64 `json:"target,omitempty"`
+}
+
+// ProjectReport aggregates logged hours by project, ticket and type.
+type ProjectReport struct {
+	From     string      `json:"from"`
+	To       string      `json:"to"`
+	Total    float64     `json:"total"`
+	Projects []ReportRow `json:"projects"`
+	Tickets  []ReportRow `json:"tickets"`
+	Types    []ReportRow `json:"types"`
+}
+
+// BuildProjectReport aggregates timesheets by project (alias when one
+// matches, ProjectName otherwise), ticket and timesheet type. Aliases that
+// declare a TargetShare always show up among the projects, even with no
+// hours logged.
+func BuildProjectReport(
+	timesheets []mantis.TimesheetsResponse,
+	prof config.Profile,
+	from, to string,
+) ProjectReport {
+	report := ProjectReport{From: from, To: to}
+
+	projects := map[string]*ReportRow{}
+	tickets := map[string]*ReportRow{}
+	tsTypes := map[string]*ReportRow{}
+
+	add := func(m map[string]*ReportRow, key string, hours float64) {
+		row, ok := m[key]
+		if !ok {
+			row = &ReportRow{Key: key}
+			m[key] = row
+		}
+		row.Hours += hours
+		row.Entries++
+	}
+
+	for _, ts := range timesheets {
+		report.Total += ts.Quantity
+
+		add(projects, resolveProject(ts, prof), ts.Quantity)
+
+		ticket := ts.TicketNo
+		if ticket == "" {
+			ticket = NoTicketKey
+		}
+		add(tickets, ticket, ts.Quantity)
+
+		typeName := ts.TimesheetType
+		if name, ok := types.TimesheetTypeInverseLookup[ts.TimesheetType]; ok {
+			typeName = name
+		}
+		add(tsTypes, typeName, ts.Quantity)
+	}
+
+	for alias, info := range prof.ProjectAliases {
+		if info.TargetShare <= 0 {
+			continue
+		}
+		if _, ok := projects[alias]; !ok {
+			projects[alias] = &ReportRow{Key: alias}
+		}
+		projects[alias].Target = info.TargetShare
+	}
+
+	report.Projects = finalizeRows(projects, report.Total)
+	report.Tickets = finalizeRows(tickets, report.Total)
+	report.Types = finalizeRows(tsTypes, report.Total)
+	return report
+}
+
+// finalizeRows computes percentages and sorts rows by hours, descending.
+func finalizeRows(m map[string]*ReportRow, total float64) []ReportRow {
+	rows := make([]ReportRow, 0, len(m))
+	for _, row := range m {
+		if total > 0 {
+			row.Percent = row.Hours / total * 100
+		}
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Hours != rows[j].Hours {
+			return rows[i].Hours > rows[j].Hours
+		}
+		return rows[i].Key < rows[j].Key
+	})
+	return rows
+}
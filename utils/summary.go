@@ -118,8 +118,13 @@ func classify(journeyHours, hours, minHours, tol float64) string {
 }
 
 func workloadBar(hours, target float64) string {
-	units := clamp(int((hours/target)*8), 0, 8)
-	return strings.Repeat("█", units) + strings.Repeat("░", 8-units)
+	return Bar(hours/target, 8)
+}
+
+// Bar renders fraction (0..1) as a horizontal bar width cells wide.
+func Bar(fraction float64, width int) string {
+	units := clamp(int(fraction*float64(width)), 0, width)
+	return strings.Repeat("█", units) + strings.Repeat("░", width-units)
 }
 
 func clamp(v, min, max int) int {
@@ -0,0 +1,407 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Salvadego/IntraCLI/config"
+	"github.com/fatih/color"
+)
+
+// ColorDepth is how many colors the terminal can show.
+type ColorDepth int
+
+const (
+	Depth16 ColorDepth = iota
+	Depth256
+	DepthTrueColor
+)
+
+// ParseColorDepth reads a depth as written in the config.
+func ParseColorDepth(s string) (ColorDepth, error) {
+	switch strings.ToLower(s) {
+	case "truecolor", "24bit":
+		return DepthTrueColor, nil
+	case "256":
+		return Depth256, nil
+	case "16":
+		return Depth16, nil
+	}
+	return 0, fmt.Errorf("invalid color depth %q: expected truecolor, 256 or 16", s)
+}
+
+// DetectColorDepth guesses the depth from COLORTERM and TERM.
+func DetectColorDepth() ColorDepth {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return DepthTrueColor
+	}
+	if os.Getenv("WT_SESSION") != "" {
+		return DepthTrueColor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return Depth256
+	}
+	return Depth16
+}
+
+// NoColorRequested reports whether NO_COLOR is set (https://no-color.org).
+func NoColorRequested() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// BuiltinThemes are the themes that need no configuration.
+var BuiltinThemes = map[string]config.Theme{
+	"default": {
+		Gradient:       []string{"#ea6962", "#e78a4e", "#a9b665"},
+		Holiday:        "cyan",
+		Weekend:        "blue",
+		Today:          "red",
+		Missing:        "red",
+		Title:          "cyan bold",
+		Section:        "yellow bold",
+		Error:          "red bold",
+		Success:        "green",
+		Muted:          "hiblack",
+		Match:          "black bg:yellow",
+		PriorityHigh:   "red bold",
+		PriorityMedium: "yellow",
+		PriorityLow:    "green",
+		SLABad:         "red",
+		SLAWarn:        "yellow",
+		SLAGood:        "green",
+	},
+	// light keeps contrast on white backgrounds.
+	"light": {
+		Gradient:       []string{"#c14a4a", "#b47109", "#6c782e"},
+		Holiday:        "#076678",
+		Weekend:        "#45707a",
+		Today:          "#c14a4a bold",
+		Missing:        "#c14a4a",
+		Title:          "#076678 bold",
+		Section:        "#b57614 bold",
+		Error:          "#9d0006 bold",
+		Success:        "#79740e",
+		Muted:          "#7c6f64",
+		Match:          "black bg:#fabd2f",
+		PriorityHigh:   "#9d0006 bold",
+		PriorityMedium: "#b57614",
+		PriorityLow:    "#79740e",
+		SLABad:         "#9d0006",
+		SLAWarn:        "#b57614",
+		SLAGood:        "#79740e",
+	},
+	// colorblind uses the Okabe-Ito palette, which avoids red against
+	// green.
+	"colorblind": {
+		Gradient:       []string{"#d55e00", "#e69f00", "#56b4e9"},
+		Holiday:        "#cc79a7",
+		Weekend:        "#0072b2",
+		Today:          "#d55e00 bold",
+		Missing:        "#d55e00",
+		Title:          "#56b4e9 bold",
+		Section:        "#e69f00 bold",
+		Error:          "#d55e00 bold",
+		Success:        "#56b4e9",
+		Muted:          "hiblack",
+		Match:          "black bg:#f0e442",
+		PriorityHigh:   "#d55e00 bold",
+		PriorityMedium: "#e69f00",
+		PriorityLow:    "#56b4e9",
+		SLABad:         "#d55e00",
+		SLAWarn:        "#e69f00",
+		SLAGood:        "#56b4e9",
+	},
+}
+
+// ThemeNames lists the built-in themes and the custom ones, sorted.
+func ThemeNames(custom map[string]config.Theme) []string {
+	var names []string
+	for n := range BuiltinThemes {
+		names = append(names, n)
+	}
+	for n := range custom {
+		if _, ok := BuiltinThemes[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ActiveTheme is the theme in use, with every color set.
+var ActiveTheme = BuiltinThemes["default"]
+
+var activeDepth = DetectColorDepth()
+
+// ResolveTheme finds the theme called name, custom themes first, and fills
+// its unset colors from its base.
+func ResolveTheme(name string, custom map[string]config.Theme) (config.Theme, error) {
+	if name == "" {
+		name = "default"
+	}
+	t, ok := custom[name]
+	if !ok {
+		if t, ok = BuiltinThemes[name]; !ok {
+			return config.Theme{}, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(ThemeNames(custom), ", "))
+		}
+		return t, nil
+	}
+
+	baseName := t.Base
+	if baseName == "" {
+		baseName = "default"
+	}
+	base, ok := BuiltinThemes[baseName]
+	if !ok {
+		return config.Theme{}, fmt.Errorf("theme %q: unknown base %q", name, baseName)
+	}
+	if len(t.Gradient) == 0 {
+		t.Gradient = base.Gradient
+	}
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{
+		{&t.Holiday, base.Holiday}, {&t.Weekend, base.Weekend}, {&t.Today, base.Today}, {&t.Missing, base.Missing},
+		{&t.Title, base.Title}, {&t.Section, base.Section}, {&t.Error, base.Error},
+		{&t.Success, base.Success}, {&t.Muted, base.Muted}, {&t.Match, base.Match},
+		{&t.PriorityHigh, base.PriorityHigh}, {&t.PriorityMedium, base.PriorityMedium}, {&t.PriorityLow, base.PriorityLow},
+		{&t.SLABad, base.SLABad}, {&t.SLAWarn, base.SLAWarn}, {&t.SLAGood, base.SLAGood},
+	} {
+		if *f.dst == "" {
+			*f.dst = f.src
+		}
+	}
+	return t, nil
+}
+
+// ApplyTheme makes t the active theme at the given depth and restyles the
+// shared styles.
+func ApplyTheme(t config.Theme, depth ColorDepth) error {
+	if len(t.Gradient) != 3 {
+		return fmt.Errorf("theme gradient needs 3 stops, got %d", len(t.Gradient))
+	}
+	for _, stop := range t.Gradient {
+		if _, _, _, ok := parseHex(stop); !ok {
+			return fmt.Errorf("theme gradient: %q is not a #rrggbb color", stop)
+		}
+	}
+
+	styles := []struct {
+		style **color.Color
+		spec  string
+	}{
+		{&TitleStyle, t.Title}, {&SectionStyle, t.Section}, {&ErrorStyle, t.Error},
+		{&SuccessStyle, t.Success}, {&MutedStyle, t.Muted}, {&MatchStyle, t.Match},
+		{&HighPriority, t.PriorityHigh}, {&MediumPriority, t.PriorityMedium}, {&LowPriority, t.PriorityLow},
+		{&SlaBad, t.SLABad}, {&SlaWarn, t.SLAWarn}, {&SlaGood, t.SLAGood},
+	}
+	built := make([]*color.Color, len(styles))
+	for i, s := range styles {
+		attrs, err := specAttributes(s.spec, depth)
+		if err != nil {
+			return err
+		}
+		built[i] = color.New(attrs...)
+	}
+	for _, spec := range []string{t.Holiday, t.Weekend, t.Today, t.Missing} {
+		if _, err := specAttributes(spec, depth); err != nil {
+			return err
+		}
+	}
+
+	for i, s := range styles {
+		*s.style = built[i]
+	}
+	ActiveTheme, activeDepth = t, depth
+	return nil
+}
+
+// SGR is the escape sequence that starts a theme color such as
+// ActiveTheme.Holiday, or "" under NO_COLOR. Invalid colors give "", as
+// ApplyTheme rejects them.
+func SGR(spec string) string {
+	if NoColorRequested() {
+		return ""
+	}
+	attrs, err := specAttributes(spec, activeDepth)
+	if err != nil || len(attrs) == 0 {
+		return ""
+	}
+	return sgr(attrs)
+}
+
+// RGBSGR is the foreground escape sequence of an RGB color at the active
+// depth.
+func RGBSGR(r, g, b uint8) string {
+	if NoColorRequested() {
+		return ""
+	}
+	return sgr(rgbAttributes(r, g, b, false, activeDepth))
+}
+
+func sgr(attrs []color.Attribute) string {
+	codes := make([]string, len(attrs))
+	for i, a := range attrs {
+		codes[i] = strconv.Itoa(int(a))
+	}
+	return "\033[" + strings.Join(codes, ";") + "m"
+}
+
+var ansiNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// specAttributes parses a theme color: a color, then any of bold,
+// underline, italic and bg:COLOR.
+func specAttributes(spec string, depth ColorDepth) ([]color.Attribute, error) {
+	var attrs []color.Attribute
+	for _, tok := range strings.Fields(strings.ToLower(spec)) {
+		switch tok {
+		case "bold":
+			attrs = append(attrs, color.Bold)
+			continue
+		case "underline":
+			attrs = append(attrs, color.Underline)
+			continue
+		case "italic":
+			attrs = append(attrs, color.Italic)
+			continue
+		}
+		name, bg := strings.CutPrefix(tok, "bg:")
+		a, err := colorAttributes(name, bg, depth)
+		if err != nil {
+			return nil, fmt.Errorf("theme color %q: %w", spec, err)
+		}
+		attrs = append(attrs, a...)
+	}
+	return attrs, nil
+}
+
+func colorAttributes(name string, bg bool, depth ColorDepth) ([]color.Attribute, error) {
+	if r, g, b, ok := parseHex(name); ok {
+		return rgbAttributes(r, g, b, bg, depth), nil
+	}
+	base := name
+	bright := false
+	if n, ok := strings.CutPrefix(name, "hi"); ok {
+		base, bright = n, true
+	}
+	i := slices.Index(ansiNames, base)
+	if i < 0 {
+		return nil, fmt.Errorf("unknown color %q", name)
+	}
+	return []color.Attribute{ansiCode(i, bright, bg)}, nil
+}
+
+func ansiCode(i int, bright, bg bool) color.Attribute {
+	code := 30 + i
+	if bright {
+		code += 60
+	}
+	if bg {
+		code += 10
+	}
+	return color.Attribute(code)
+}
+
+func rgbAttributes(r, g, b uint8, bg bool, depth ColorDepth) []color.Attribute {
+	lead := color.Attribute(38)
+	if bg {
+		lead = 48
+	}
+	switch depth {
+	case DepthTrueColor:
+		return []color.Attribute{lead, 2, color.Attribute(r), color.Attribute(g), color.Attribute(b)}
+	case Depth256:
+		return []color.Attribute{lead, 5, color.Attribute(xterm256(r, g, b))}
+	}
+	i, bright := ansi16(r, g, b)
+	return []color.Attribute{ansiCode(i, bright, bg)}
+}
+
+func parseHex(s string) (r, g, b uint8, ok bool) {
+	if len(s) != 7 || s[0] != '#' {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), true
+}
+
+// xterm256 is the nearest color of the 6x6x6 cube or the gray ramp.
+func xterm256(r, g, b uint8) int {
+	levels := []int{0, 95, 135, 175, 215, 255}
+	nearest := func(v uint8) int {
+		best := 0
+		for i, l := range levels {
+			if abs(int(v)-l) < abs(int(v)-levels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	ri, gi, bi := nearest(r), nearest(g), nearest(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := sq(int(r)-levels[ri]) + sq(int(g)-levels[gi]) + sq(int(b)-levels[bi])
+
+	avg := (int(r) + int(g) + int(b)) / 3
+	grayIdx := min(23, max(0, (avg-8+5)/10))
+	gray := 8 + 10*grayIdx
+	grayDist := sq(int(r)-gray) + sq(int(g)-gray) + sq(int(b)-gray)
+	if grayDist < cubeDist {
+		return 232 + grayIdx
+	}
+	return cube
+}
+
+// ansi16 picks the basic color of the same hue; nearly gray colors become
+// black, gray or white.
+func ansi16(r, g, b uint8) (int, bool) {
+	hi := max(r, g, b)
+	lo := min(r, g, b)
+	light := (int(hi) + int(lo)) / 2
+	if int(hi)-int(lo) < 40 {
+		switch {
+		case light < 64:
+			return 0, false
+		case light < 160:
+			return 0, true
+		case light < 224:
+			return 7, false
+		}
+		return 7, true
+	}
+
+	var hue float64
+	d := float64(hi) - float64(lo)
+	switch hi {
+	case r:
+		hue = 60 * (float64(g) - float64(b)) / d
+	case g:
+		hue = 60 * (2 + (float64(b)-float64(r))/d)
+	default:
+		hue = 60 * (4 + (float64(r)-float64(g))/d)
+	}
+	if hue < 0 {
+		hue += 360
+	}
+	// red, yellow, green, cyan, blue, magenta every 60 degrees.
+	sector := []int{1, 3, 2, 6, 4, 5}[int(hue+30)/60%6]
+	return sector, light > 127
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sq(v int) int {
+	return v * v
+}
@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Salvadego/mantis/mantis"
+)
+
+// AgeBucket is a half-open range of ticket ages in days: [Min, Max).
+// Max 0 means unbounded.
+type AgeBucket struct {
+	Label string `json:"label"`
+	Min   int    `json:"min"`
+	Max   int    `json:"max,omitempty"`
+}
+
+// AgeBuckets are the columns of the aging report.
+var AgeBuckets = []AgeBucket{
+	{"<3d", 0, 3},
+	{"3-7d", 3, 8},
+	{"8-14d", 8, 15},
+	{"15-30d", 15, 31},
+	{"31-90d", 31, 91},
+	{">90d", 91, 0},
+}
+
+func ageBucket(days float64) int {
+	for i, b := range AgeBuckets {
+		if int(days) >= b.Min && (b.Max == 0 || int(days) < b.Max) {
+			return i
+		}
+	}
+	return len(AgeBuckets) - 1
+}
+
+// AgeRow counts the tickets of one status or priority per age bucket.
+type AgeRow struct {
+	Key        string  `json:"key"`
+	Counts     []int   `json:"counts"`
+	Total      int     `json:"total"`
+	MedianDays float64 `json:"medianDays"`
+	// Rank orders priority rows; it is 0 for status rows.
+	Rank int `json:"-"`
+}
+
+// SLARow counts the tickets in one SLA band.
+type SLARow struct {
+	Band    string  `json:"band"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// TicketSnapshot is the headline numbers of a ticket report, kept in the
+// cache to compare reports over time.
+type TicketSnapshot struct {
+	Time       time.Time `json:"time"`
+	Total      int       `json:"total"`
+	Breached   int       `json:"breached"`
+	AtRisk     int       `json:"atRisk"`
+	MedianDays float64   `json:"medianDays"`
+	P90Days    float64   `json:"p90Days"`
+}
+
+// TicketReport is the aging and SLA analysis of a set of tickets.
+type TicketReport struct {
+	TicketSnapshot
+	Buckets    []AgeBucket `json:"buckets"`
+	ByStatus   []AgeRow    `json:"byStatus"`
+	ByPriority []AgeRow    `json:"byPriority"`
+	SLA        []SLARow    `json:"sla"`
+	// Baseline is the earlier snapshot the report is compared against.
+	Baseline *TicketSnapshot `json:"baseline,omitempty"`
+}
+
+// slaBandNames label the SLA bands, worst first.
+var slaBandNames = []string{
+	SLABreached: "breached (0%)",
+	SLABad:      "at risk (<50%)",
+	SLAWarn:     "warning (50-79%)",
+	SLAGood:     "ok (80%+)",
+}
+
+// BuildTicketReport ages tickets as of now and summarizes their SLA. Tickets
+// whose SLA is used up count as breached; those in the band below 50% as
+// at risk.
+func BuildTicketReport(tickets []mantis.TicketResponse, now time.Time) TicketReport {
+	report := TicketReport{
+		TicketSnapshot: TicketSnapshot{Time: now, Total: len(tickets)},
+		Buckets:        AgeBuckets,
+	}
+
+	type group struct {
+		row  *AgeRow
+		ages []float64
+	}
+	statuses := map[string]*group{}
+	priorities := map[string]*group{}
+	add := func(m map[string]*group, key string, rank int, age float64) {
+		g, ok := m[key]
+		if !ok {
+			g = &group{row: &AgeRow{Key: key, Counts: make([]int, len(AgeBuckets)), Rank: rank}}
+			m[key] = g
+		}
+		g.row.Counts[ageBucket(age)]++
+		g.row.Total++
+		g.ages = append(g.ages, age)
+	}
+
+	bands := make([]int, len(slaBandNames))
+	var ages []float64
+	for _, t := range tickets {
+		age := 0.0
+		if created, err := time.ParseInLocation("20060102150405", t.TicketCreated, now.Location()); err == nil {
+			age = max(0, now.Sub(created).Hours()/24)
+		}
+		ages = append(ages, age)
+
+		add(statuses, t.Status, 0, age)
+		priority := t.Priority
+		if priority == "" {
+			priority = "(none)"
+		}
+		add(priorities, priority, PriorityRank(t.Priority), age)
+
+		band := SLABand(ParsePercent(t.PercSLA))
+		bands[band]++
+		switch band {
+		case SLABreached:
+			report.Breached++
+		case SLABad:
+			report.AtRisk++
+		}
+	}
+
+	report.MedianDays = Percentile(ages, 50)
+	report.P90Days = Percentile(ages, 90)
+
+	rows := func(m map[string]*group) []AgeRow {
+		out := make([]AgeRow, 0, len(m))
+		for _, g := range m {
+			g.row.MedianDays = Percentile(g.ages, 50)
+			out = append(out, *g.row)
+		}
+		sort.Slice(out, func(i, j int) bool {
+			if out[i].Rank != out[j].Rank {
+				return out[i].Rank < out[j].Rank
+			}
+			return out[i].Key < out[j].Key
+		})
+		return out
+	}
+	report.ByStatus = rows(statuses)
+	report.ByPriority = rows(priorities)
+
+	for band, name := range slaBandNames {
+		row := SLARow{Band: name, Count: bands[band]}
+		if len(tickets) > 0 {
+			row.Percent = float64(row.Count) / float64(len(tickets)) * 100
+		}
+		report.SLA = append(report.SLA, row)
+	}
+	return report
+}
+
+// Percentile returns the p-th percentile of values by linear interpolation
+// between closest ranks, or 0 for no values.
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	pos := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+// TrendBaseline picks the snapshot to compare against: the most recent one
+// taken at least `since` before now, or else the oldest one available.
+// It returns nil when history is empty.
+func TrendBaseline(history []TicketSnapshot, now time.Time, since time.Duration) *TicketSnapshot {
+	var old, oldest *TicketSnapshot
+	for i := range history {
+		s := &history[i]
+		if !s.Time.Before(now) {
+			continue
+		}
+		if !s.Time.After(now.Add(-since)) && (old == nil || s.Time.After(old.Time)) {
+			old = s
+		}
+		if oldest == nil || s.Time.Before(oldest.Time) {
+			oldest = s
+		}
+	}
+	if old != nil {
+		return old
+	}
+	return oldest
+}
+
+// AddSnapshot appends s to history, replacing a snapshot from the same day,
+// and keeps at most limit entries.
+func AddSnapshot(history []TicketSnapshot, s TicketSnapshot, limit int) []TicketSnapshot {
+	day := s.Time.Format("2006-01-02")
+	out := make([]TicketSnapshot, 0, len(history)+1)
+	for _, h := range history {
+		if h.Time.Format("2006-01-02") != day {
+			out = append(out, h)
+		}
+	}
+	out = append(out, s)
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	if len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+	return out
+}
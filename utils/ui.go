@@ -13,6 +13,7 @@ var (
 	ErrorStyle   = color.New(color.FgRed, color.Bold)
 	SuccessStyle = color.New(color.FgGreen)
 	MutedStyle   = color.New(color.FgHiBlack)
+	MatchStyle   = color.New(color.FgBlack, color.BgYellow)
 
 	HighPriority   = color.New(color.FgRed, color.Bold)
 	MediumPriority = color.New(color.FgYellow)
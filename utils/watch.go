@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Salvadego/mantis/mantis"
+)
+
+type TicketChangeKind string
+
+const (
+	ChangeNew      TicketChangeKind = "new"
+	ChangeGone     TicketChangeKind = "gone"
+	ChangeStatus   TicketChangeKind = "status"
+	ChangePriority TicketChangeKind = "priority"
+	ChangeSLA      TicketChangeKind = "sla"
+	ChangeComment  TicketChangeKind = "comment"
+)
+
+// TicketChange is one difference between two ticket report snapshots.
+type TicketChange struct {
+	Kind   TicketChangeKind
+	Ticket mantis.TicketResponse
+	From   string
+	To     string
+	// Text is set for ChangeComment.
+	Text *mantis.Text
+}
+
+// SLA bands follow the colors of the tickets listing: good from 80%, warn
+// from 50%, bad below, breached at 0.
+const (
+	SLABreached = iota
+	SLABad
+	SLAWarn
+	SLAGood
+)
+
+// ParsePercent parses Mantis percentages such as "73%".
+func ParsePercent(s string) int {
+	v, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	return v
+}
+
+func SLABand(percent int) int {
+	switch {
+	case percent <= 0:
+		return SLABreached
+	case percent < 50:
+		return SLABad
+	case percent < 80:
+		return SLAWarn
+	default:
+		return SLAGood
+	}
+}
+
+// DiffTickets compares two report snapshots. SLA changes are only reported
+// when a ticket falls into a worse band, since the percentage decreases on
+// every poll.
+func DiffTickets(prev, curr []mantis.TicketResponse) []TicketChange {
+	before := make(map[string]mantis.TicketResponse, len(prev))
+	for _, t := range prev {
+		before[t.TicketNumber] = t
+	}
+
+	var changes []TicketChange
+	seen := make(map[string]bool, len(curr))
+	for _, t := range curr {
+		seen[t.TicketNumber] = true
+
+		old, ok := before[t.TicketNumber]
+		if !ok {
+			changes = append(changes, TicketChange{Kind: ChangeNew, Ticket: t})
+			continue
+		}
+		if old.Status != t.Status {
+			changes = append(changes, TicketChange{Kind: ChangeStatus, Ticket: t, From: old.Status, To: t.Status})
+		}
+		if old.Priority != t.Priority {
+			changes = append(changes, TicketChange{Kind: ChangePriority, Ticket: t, From: old.Priority, To: t.Priority})
+		}
+		if SLABand(ParsePercent(t.PercSLA)) < SLABand(ParsePercent(old.PercSLA)) {
+			changes = append(changes, TicketChange{Kind: ChangeSLA, Ticket: t, From: old.PercSLA, To: t.PercSLA})
+		}
+	}
+
+	for _, t := range prev {
+		if !seen[t.TicketNumber] {
+			changes = append(changes, TicketChange{Kind: ChangeGone, Ticket: t})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Ticket.TicketNumber < changes[j].Ticket.TicketNumber
+	})
+	return changes
+}
+
+// NewTexts returns the texts in curr that are not in prev, matched by GUID,
+// oldest first.
+func NewTexts(prev, curr []mantis.Text) []mantis.Text {
+	known := make(map[string]bool, len(prev))
+	for _, tx := range prev {
+		known[tx.GUID] = true
+	}
+
+	var out []mantis.Text
+	for _, tx := range curr {
+		if !known[tx.GUID] {
+			out = append(out, tx)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].TDFCreatedAt.Before(out[j].TDFCreatedAt) })
+	return out
+}